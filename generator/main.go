@@ -4,15 +4,13 @@ import (
 	"flag"
 	"fmt"
 	"go/ast"
-	"go/parser"
-	"go/token"
 	"go/types"
 	"os"
-	"os/exec"
-	"path/filepath"
 	"sort"
 	"strings"
 	"text/template"
+
+	"golang.org/x/tools/go/packages"
 )
 
 type FuncInfo struct {
@@ -23,6 +21,28 @@ type FuncInfo struct {
 	Doc         string
 	ReturnsErr  bool
 	MultiReturn bool // Has multiple non-error return values
+	// Recv is the exported type this func is a method of (e.g. "Buffer").
+	// Empty for plain package-level functions.
+	Recv string
+	// Iter is set when the function's sole return value is a range-over-func
+	// iter.Seq[T] (strings.SplitSeq, slices.Values, ...) rather than a
+	// materialized value. When set, Results/ReturnsErr/MultiReturn are all
+	// left zero and the templates emit a pull-style adapter instead.
+	Iter *IterInfo
+}
+
+// IterInfo describes an iter.Seq[T] return value: the element type pulled
+// one at a time off the goroutine-driven adapter in golib/iter.go.
+type IterInfo struct {
+	Elem ParamInfo // T in iter.Seq[T]
+}
+
+// TypeInfo describes an exported type whose methods are reachable from
+// Python only through an opaque handle, since cgo can't pass a Go struct
+// by value across the boundary.
+type TypeInfo struct {
+	Name    string
+	Methods []FuncInfo
 }
 
 type ParamInfo struct {
@@ -38,6 +58,13 @@ type PackageInfo struct {
 	Name      string
 	GoPackage string
 	Functions []FuncInfo
+	// Imports holds extra packages referenced by parameter/result types
+	// (e.g. a function in "path/filepath" that takes an "io/fs".FileMode)
+	// that must be imported alongside GoPackage in the generated Go file.
+	Imports []string
+	// Types holds exported named types with at least one bindable method
+	// (bytes.Buffer, regexp.Regexp, strings.Builder, ...).
+	Types []TypeInfo
 }
 
 func main() {
@@ -51,7 +78,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	pkg, err := parseStdlibPackage(*pkgPath)
+	pkg, err := parseGoPackage(*pkgPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing package: %v\n", err)
 		os.Exit(1)
@@ -78,78 +105,234 @@ func main() {
 	}
 }
 
-func parseStdlibPackage(pkgName string) (*PackageInfo, error) {
-	goroot := os.Getenv("GOROOT")
-	if goroot == "" {
-		out, err := exec.Command("go", "env", "GOROOT").Output()
-		if err == nil {
-			goroot = strings.TrimSpace(string(out))
-		}
+// parseGoPackage loads pkgPath with go/packages (so it resolves through
+// GOPATH/module mode exactly like the `go` tool would) and walks its
+// type-checked syntax trees for bindable top-level functions. Unlike a bare
+// go/parser pass, every parameter and result comes back as a resolved
+// types.Object, which lets typeToParamInfo see through named types and
+// aliases (os.FileMode, time.Duration, ...) to the primitive they're built
+// on instead of rejecting them outright.
+func parseGoPackage(pkgPath string) (*PackageInfo, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax | packages.NeedFiles,
 	}
-	if goroot == "" {
-		goroot = "/usr/local/go"
+	pkgs, err := packages.Load(cfg, pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load package %s: %w", pkgPath, err)
 	}
-
-	pkgDir := filepath.Join(goroot, "src", pkgName)
-	if _, err := os.Stat(pkgDir); os.IsNotExist(err) {
-		return nil, fmt.Errorf("package %s not found at %s", pkgName, pkgDir)
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("package %s has type errors", pkgPath)
 	}
+	if len(pkgs) == 0 || pkgs[0].Types == nil {
+		return nil, fmt.Errorf("package %s not found", pkgPath)
+	}
+	pkg := pkgs[0]
 
-	fset := token.NewFileSet()
-	pkgs, err := parser.ParseDir(fset, pkgDir, func(fi os.FileInfo) bool {
-		return !strings.HasSuffix(fi.Name(), "_test.go")
-	}, parser.ParseComments)
+	info := &PackageInfo{
+		Name:      pkg.Types.Name(),
+		GoPackage: pkgPath,
+	}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse package: %w", err)
+	// Qualifier used when stringifying resolved types back into Go source.
+	// It prints the short package name ("filepath", not "path/filepath")
+	// and records any package other than the one we're binding so its
+	// import can be emitted alongside GoPackage.
+	extraImports := make(map[string]bool)
+	qf := func(p *types.Package) string {
+		if p.Path() != pkg.PkgPath {
+			extraImports[p.Path()] = true
+		}
+		return p.Name()
 	}
 
-	shortName := pkgName
-	if idx := strings.LastIndex(pkgName, "/"); idx >= 0 {
-		shortName = pkgName[idx+1:]
+	seen := make(map[string]bool)
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil {
+				continue
+			}
+			if !ast.IsExported(fn.Name.Name) || seen[fn.Name.Name] {
+				continue
+			}
+			funcInfo := extractFuncInfo(fn, pkg.TypesInfo, qf)
+			if funcInfo != nil && isBindable(funcInfo) {
+				info.Functions = append(info.Functions, *funcInfo)
+				seen[fn.Name.Name] = true
+			}
+		}
 	}
 
-	info := &PackageInfo{
-		Name:      shortName,
-		GoPackage: pkgName,
+	info.Types = extractTypeInfos(pkg, qf)
+
+	for path := range extraImports {
+		info.Imports = append(info.Imports, path)
 	}
+	sort.Strings(info.Imports)
 
-	seen := make(map[string]bool)
-	for _, pkg := range pkgs {
-		if strings.HasSuffix(pkg.Name, "_test") {
+	sort.Slice(info.Functions, func(i, j int) bool {
+		return info.Functions[i].Name < info.Functions[j].Name
+	})
+
+	return info, nil
+}
+
+// typesWithoutValidZeroValue lists named types (import path + "." + name)
+// whose zero value isn't safe to call methods on -- the generated
+// constructor thunk can only emit new(T), and some types need a real
+// constructor function instead (regexp.Regexp needs regexp.Compile; calling
+// FindString on a zero-value Regexp panics with an out-of-range slice
+// bounds error). Since the generated thunks have no recover(), that panic would be
+// fatal to the whole process across the cgo boundary, so these types are
+// skipped entirely until this generator can emit a real constructor call
+// for them instead of new(T).
+var typesWithoutValidZeroValue = map[string]bool{
+	"regexp.Regexp": true,
+}
+
+// extractTypeInfos enumerates the package's exported named types and, for
+// each one with at least one bindable method, records that method set so
+// the templates can emit a handle-backed constructor/finalizer plus one
+// thunk per method (e.g. bytes.Buffer.WriteString, strings.Builder.Grow).
+func extractTypeInfos(pkg *packages.Package, qf types.Qualifier) []TypeInfo {
+	docs := methodDocs(pkg)
+
+	var result []TypeInfo
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		if !ast.IsExported(name) {
+			continue
+		}
+		if typesWithoutValidZeroValue[pkg.Types.Path()+"."+name] {
+			continue
+		}
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		named, ok := tn.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		if _, isIface := named.Underlying().(*types.Interface); isIface {
 			continue
 		}
 
-		for _, file := range pkg.Files {
-			for _, decl := range file.Decls {
-				if fn, ok := decl.(*ast.FuncDecl); ok {
-					if fn.Recv != nil {
-						continue
-					}
-					if !ast.IsExported(fn.Name.Name) {
-						continue
-					}
-					if seen[fn.Name.Name] {
-						continue
-					}
-					funcInfo := extractFuncInfo(fn)
-					if funcInfo != nil && isBindable(funcInfo) {
-						info.Functions = append(info.Functions, *funcInfo)
-						seen[fn.Name.Name] = true
-					}
-				}
+		ti := TypeInfo{Name: name}
+		mset := types.NewMethodSet(types.NewPointer(named))
+		for i := 0; i < mset.Len(); i++ {
+			mfn, ok := mset.At(i).Obj().(*types.Func)
+			if !ok || !mfn.Exported() {
+				continue
+			}
+			fn := methodFuncInfo(mfn, name, docs[name+"."+mfn.Name()], qf)
+			if fn != nil && isBindable(fn) {
+				ti.Methods = append(ti.Methods, *fn)
 			}
 		}
+		sort.Slice(ti.Methods, func(i, j int) bool {
+			return ti.Methods[i].Name < ti.Methods[j].Name
+		})
+		if len(ti.Methods) > 0 {
+			result = append(result, ti)
+		}
 	}
 
-	sort.Slice(info.Functions, func(i, j int) bool {
-		return info.Functions[i].Name < info.Functions[j].Name
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
 	})
+	return result
+}
 
-	return info, nil
+func methodFuncInfo(mfn *types.Func, recv, doc string, qf types.Qualifier) *FuncInfo {
+	sig, ok := mfn.Type().(*types.Signature)
+	if !ok {
+		return nil
+	}
+
+	info := &FuncInfo{
+		Name:   mfn.Name(),
+		GoName: mfn.Name(),
+		Doc:    doc,
+		Recv:   recv,
+	}
+
+	params := sig.Params()
+	for i := 0; i < params.Len(); i++ {
+		paramInfo := typeToParamInfo(params.At(i).Type(), qf)
+		if paramInfo == nil {
+			return nil
+		}
+		p := *paramInfo
+		p.Name = safeParamName(params.At(i).Name(), i)
+		info.Params = append(info.Params, p)
+	}
+
+	results := sig.Results()
+	if results.Len() == 1 {
+		if it := detectIterSeq(results.At(0).Type(), qf); it != nil {
+			info.Iter = it
+			return info
+		}
+	}
+	for i := 0; i < results.Len(); i++ {
+		paramInfo := typeToParamInfo(results.At(i).Type(), qf)
+		if paramInfo == nil {
+			return nil
+		}
+		if paramInfo.GoType == "error" {
+			info.ReturnsErr = true
+			continue
+		}
+		p := *paramInfo
+		p.Name = results.At(i).Name()
+		if p.Name == "" {
+			p.Name = fmt.Sprintf("r%d", i)
+		}
+		info.Results = append(info.Results, p)
+	}
+
+	if len(info.Results) > 1 {
+		info.MultiReturn = true
+	}
+
+	return info
 }
 
-func extractFuncInfo(fn *ast.FuncDecl) *FuncInfo {
+// methodDocs maps "TypeName.MethodName" to that method's doc comment, read
+// straight from the AST since *types.Func carries no comment text.
+func methodDocs(pkg *packages.Package) map[string]string {
+	docs := make(map[string]string)
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 || fn.Doc == nil {
+				continue
+			}
+			recvType := fn.Recv.List[0].Type
+			if star, ok := recvType.(*ast.StarExpr); ok {
+				recvType = star.X
+			}
+			ident, ok := recvType.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			docs[ident.Name+"."+fn.Name.Name] = strings.TrimSpace(fn.Doc.Text())
+		}
+	}
+	return docs
+}
+
+func extractFuncInfo(fn *ast.FuncDecl, typesInfo *types.Info, qf types.Qualifier) *FuncInfo {
+	obj, ok := typesInfo.Defs[fn.Name]
+	if !ok || obj == nil {
+		return nil
+	}
+	sig, ok := obj.Type().(*types.Signature)
+	if !ok {
+		return nil
+	}
+
 	info := &FuncInfo{
 		Name:   fn.Name.Name,
 		GoName: fn.Name.Name,
@@ -159,49 +342,39 @@ func extractFuncInfo(fn *ast.FuncDecl) *FuncInfo {
 		info.Doc = strings.TrimSpace(fn.Doc.Text())
 	}
 
-	if fn.Type.Params != nil {
-		paramIdx := 0
-		for _, field := range fn.Type.Params.List {
-			paramInfo := typeToParamInfo(field.Type)
-			if paramInfo == nil {
-				return nil
-			}
-			for _, name := range field.Names {
-				p := *paramInfo
-				p.Name = safeParamName(name.Name, paramIdx)
-				paramIdx++
-				info.Params = append(info.Params, p)
-			}
-			if len(field.Names) == 0 {
-				p := *paramInfo
-				p.Name = fmt.Sprintf("arg%d", paramIdx)
-				paramIdx++
-				info.Params = append(info.Params, p)
-			}
+	params := sig.Params()
+	for i := 0; i < params.Len(); i++ {
+		paramInfo := typeToParamInfo(params.At(i).Type(), qf)
+		if paramInfo == nil {
+			return nil
 		}
+		p := *paramInfo
+		p.Name = safeParamName(params.At(i).Name(), i)
+		info.Params = append(info.Params, p)
 	}
 
-	if fn.Type.Results != nil {
-		for i, field := range fn.Type.Results.List {
-			paramInfo := typeToParamInfo(field.Type)
-			if paramInfo == nil {
-				return nil
-			}
-			if paramInfo.GoType == "error" {
-				info.ReturnsErr = true
-				continue
-			}
-			for _, name := range field.Names {
-				p := *paramInfo
-				p.Name = name.Name
-				info.Results = append(info.Results, p)
-			}
-			if len(field.Names) == 0 {
-				p := *paramInfo
-				p.Name = fmt.Sprintf("r%d", i)
-				info.Results = append(info.Results, p)
-			}
+	results := sig.Results()
+	if results.Len() == 1 {
+		if it := detectIterSeq(results.At(0).Type(), qf); it != nil {
+			info.Iter = it
+			return info
+		}
+	}
+	for i := 0; i < results.Len(); i++ {
+		paramInfo := typeToParamInfo(results.At(i).Type(), qf)
+		if paramInfo == nil {
+			return nil
 		}
+		if paramInfo.GoType == "error" {
+			info.ReturnsErr = true
+			continue
+		}
+		p := *paramInfo
+		p.Name = results.At(i).Name()
+		if p.Name == "" {
+			p.Name = fmt.Sprintf("r%d", i)
+		}
+		info.Results = append(info.Results, p)
 	}
 
 	if len(info.Results) > 1 {
@@ -211,39 +384,75 @@ func extractFuncInfo(fn *ast.FuncDecl) *FuncInfo {
 	return info
 }
 
-func typeToParamInfo(expr ast.Expr) *ParamInfo {
-	switch t := expr.(type) {
-	case *ast.Ident:
-		return basicTypeToParam(t.Name)
-	case *ast.ArrayType:
-		if t.Len == nil {
-			elem := typeToParamInfo(t.Elt)
-			if elem == nil {
-				return nil
-			}
-			return &ParamInfo{
-				GoType:  "[]" + elem.GoType,
-				CType:   "C.ulonglong",
-				PyType:  "list",
-				IsSlice: true,
-			}
+// typeToParamInfo walks a resolved types.Type down to the primitive it's
+// built on. *types.Named and *types.Alias are unwrapped via their
+// Underlying() until a *types.Basic is reached (so os.FileMode binds as
+// uint32 and time.Duration binds as int64), but GoType keeps the original,
+// qualified spelling so the generated code still passes os.FileMode /
+// time.Duration around instead of the bare primitive.
+func typeToParamInfo(t types.Type, qf types.Qualifier) *ParamInfo {
+	t = types.Unalias(t)
+
+	switch u := t.(type) {
+	case *types.Basic:
+		return basicTypeToParam(u.Name())
+	case *types.Named:
+		if t.String() == "error" {
+			return &ParamInfo{GoType: "error", CType: "**C.char", PyType: "str"}
 		}
+		leaf := typeToParamInfo(u.Underlying(), qf)
+		if leaf == nil {
+			return nil
+		}
+		leaf.GoType = types.TypeString(t, qf)
+		return leaf
+	case *types.Slice:
+		elem := typeToParamInfo(u.Elem(), qf)
+		if elem == nil {
+			return nil
+		}
+		pyType := "list"
+		if elem.GoType == "byte" {
+			pyType = "bytes"
+		}
+		return &ParamInfo{
+			GoType:  "[]" + elem.GoType,
+			CType:   "C.ulonglong",
+			PyType:  pyType,
+			IsSlice: true,
+		}
+	default:
 		return nil
-	case *ast.StarExpr:
-		return nil
-	case *ast.SelectorExpr:
-		return nil
-	case *ast.InterfaceType:
+	}
+}
+
+// detectIterSeq recognizes a result type shaped like the standard library's
+// iter.Seq[T] (Go 1.23 range-over-func): a defined type over
+// func(yield func(T) bool). Only single-element sequences are recognized;
+// iter.Seq2[K, V] is left to a future pass since nothing in this package
+// set returns one yet.
+func detectIterSeq(t types.Type, qf types.Qualifier) *IterInfo {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
 		return nil
-	case *ast.FuncType:
+	}
+	sig, ok := named.Underlying().(*types.Signature)
+	if !ok || sig.Params().Len() != 1 || sig.Results().Len() != 0 {
 		return nil
-	case *ast.MapType:
+	}
+	yield, ok := sig.Params().At(0).Type().(*types.Signature)
+	if !ok || yield.Params().Len() != 1 || yield.Results().Len() != 1 {
 		return nil
-	case *ast.ChanType:
+	}
+	ret, ok := yield.Results().At(0).Type().(*types.Basic)
+	if !ok || ret.Kind() != types.Bool {
 		return nil
-	default:
+	}
+	elem := typeToParamInfo(yield.Params().At(0).Type(), qf)
+	if elem == nil {
 		return nil
 	}
+	return &IterInfo{Elem: *elem}
 }
 
 var reservedNames = map[string]bool{
@@ -253,6 +462,9 @@ var reservedNames = map[string]bool{
 }
 
 func safeParamName(name string, idx int) string {
+	if name == "" || name == "_" {
+		return fmt.Sprintf("arg%d", idx)
+	}
 	if reservedNames[name] {
 		return fmt.Sprintf("%s_", name)
 	}
@@ -298,21 +510,154 @@ func basicTypeToParam(name string) *ParamInfo {
 	}
 }
 
+// outCType gives the C pointer type used to write a secondary (non-primary)
+// result through a caller-supplied out-param, mirroring the errOut **C.char
+// convention already used for errors: a string result is written through a
+// **C.char (so the callee can still hand back a fresh C.CString), everything
+// else through a pointer to its own CType.
+func outCType(p ParamInfo) string {
+	if p.CType == "*C.char" {
+		return "**C.char"
+	}
+	return "*" + p.CType
+}
+
+// bindableSliceTypes lists the slice parameter shapes that have a matching
+// builder thunk in golib/slice.go (goated_slice_<elem>_new/append, or
+// goated_slice_byte_from_bytes for []byte) and a matching getXSlice helper
+// in golib/handle.go. Any other element type is rejected by isBindable.
+var bindableSliceTypes = map[string]bool{
+	"[]string": true,
+	"[]int":    true,
+	"[]byte":   true,
+}
+
+// bindableIterElems lists the iter.Seq[T] element types with a matching
+// goated_iter_<T>_next/_close pair in golib/iter.go.
+var bindableIterElems = map[string]bool{
+	"string": true,
+	"int":    true,
+	"byte":   true,
+}
+
+// sliceElemName strips the slice's "[]" prefix to get the elem-type name
+// used in both the golib/slice.go thunk names (goated_slice_<elem>_*) and
+// the Python builder helpers (_build_<elem>_slice / _free_<elem>_slice).
+func sliceElemName(goType string) string {
+	return strings.TrimPrefix(goType, "[]")
+}
+
+// sliceGetter names the golib/handle.go helper that turns a slice param's
+// handle back into a Go slice before the wrapped function is called.
+func sliceGetter(goType string) string {
+	switch sliceElemName(goType) {
+	case "string":
+		return "getStringSlice"
+	case "int":
+		return "getIntSlice"
+	default:
+		return "getByteSlice"
+	}
+}
+
+// pyCRestype, pyOutPtrType, pyOutCtor and pyOutDecode give the ctypes
+// declarations needed to marshal a secondary result out of a MultiReturn
+// thunk: the restype/argtype of the out-param, the ctypes instance to
+// allocate before the call, and how to read its .value back afterwards.
+func pyCRestype(goType string) string {
+	switch goType {
+	case "string":
+		return "ctypes.c_char_p"
+	case "bool":
+		return "ctypes.c_bool"
+	default:
+		return "ctypes.c_longlong"
+	}
+}
+
+func pyOutPtrType(goType string) string {
+	switch goType {
+	case "string":
+		return "ctypes.POINTER(ctypes.c_char_p)"
+	case "bool":
+		return "ctypes.POINTER(ctypes.c_bool)"
+	default:
+		return "ctypes.POINTER(ctypes.c_longlong)"
+	}
+}
+
+func pyOutCtor(goType string) string {
+	switch goType {
+	case "string":
+		return "ctypes.c_char_p()"
+	case "bool":
+		return "ctypes.c_bool()"
+	default:
+		return "ctypes.c_longlong()"
+	}
+}
+
+func pyOutDecode(goType, expr string) string {
+	switch goType {
+	case "string":
+		return fmt.Sprintf("_decode(%s.value)", expr)
+	case "bool":
+		return fmt.Sprintf("bool(%s.value)", expr)
+	default:
+		return fmt.Sprintf("%s.value", expr)
+	}
+}
+
+// pyIterRestype and pyIterDecode give the ctypes restype and Python-side
+// decode expression for a goated_iter_<T>_next thunk's return value, keyed
+// on the iterator's element GoType (one of bindableIterElems).
+func pyIterRestype(goType string) string {
+	switch goType {
+	case "string":
+		return "ctypes.c_char_p"
+	case "byte":
+		return "ctypes.c_ubyte"
+	default:
+		return "ctypes.c_longlong"
+	}
+}
+
+func pyIterDecode(goType, expr string) string {
+	if goType == "string" {
+		return fmt.Sprintf("_decode(%s)", expr)
+	}
+	return expr
+}
+
 func isBindable(fn *FuncInfo) bool {
+	if fn.Iter != nil {
+		// Method thunks don't start a goroutine-driven adapter off a handle
+		// receiver yet, and only the elem types with a golib/iter.go
+		// goated_iter_<T>_next/_close pair can be pulled across the boundary.
+		return fn.Recv == "" && bindableIterElems[fn.Iter.Elem.GoType]
+	}
 	for _, p := range fn.Params {
-		if p.CType == "" || p.IsSlice {
+		if p.CType == "" {
 			return false
 		}
+		if p.IsSlice {
+			// Method thunks don't consume a slice-param handle yet, and
+			// only the elem types with a golib/slice.go builder thunk
+			// (string, int, byte) can be marshaled across the boundary.
+			if fn.Recv != "" || !bindableSliceTypes[p.GoType] {
+				return false
+			}
+		}
 	}
 	for _, r := range fn.Results {
 		if r.CType == "" || r.IsSlice {
 			return false
 		}
 	}
-	if len(fn.Results) > 2 {
-		return false
-	}
-	if fn.MultiReturn && len(fn.Results) > 1 {
+	// Method thunks don't thread out-pointers through a handle receiver yet,
+	// so multi-value returns (Cut-style (value, ok) results) are limited to
+	// plain package-level functions for now.
+	if fn.MultiReturn && fn.Recv != "" {
 		return false
 	}
 	return true
@@ -355,12 +700,21 @@ func printPackageInfo(pkg *PackageInfo) {
 		}
 		fmt.Println()
 	}
+
+	if len(pkg.Types) > 0 {
+		fmt.Printf("\nBindable types: %d\n\n", len(pkg.Types))
+		for _, t := range pkg.Types {
+			fmt.Printf("  %s (%d methods)\n", t.Name, len(t.Methods))
+		}
+	}
 }
 
 func generateGoCode(pkg *PackageInfo, outPath string) error {
 	tmpl := template.Must(template.New("go").Funcs(template.FuncMap{
-		"lower":     strings.ToLower,
-		"snakeCase": toSnakeCase,
+		"lower":       strings.ToLower,
+		"snakeCase":   toSnakeCase,
+		"outCType":    outCType,
+		"sliceGetter": sliceGetter,
 	}).Parse(goTemplate))
 
 	f, err := os.Create(outPath)
@@ -374,9 +728,16 @@ func generateGoCode(pkg *PackageInfo, outPath string) error {
 
 func generatePyCode(pkg *PackageInfo, outPath string) error {
 	tmpl := template.Must(template.New("py").Funcs(template.FuncMap{
-		"lower":     strings.ToLower,
-		"snakeCase": toSnakeCase,
-		"pyDoc":     formatPyDoc,
+		"lower":         strings.ToLower,
+		"snakeCase":     toSnakeCase,
+		"pyDoc":         formatPyDoc,
+		"pyCRestype":    pyCRestype,
+		"pyOutPtr":      pyOutPtrType,
+		"pyOutCtor":     pyOutCtor,
+		"pyOutDecode":   pyOutDecode,
+		"sliceElemName": sliceElemName,
+		"pyIterRestype": pyIterRestype,
+		"pyIterDecode":  pyIterDecode,
 	}).Parse(pyTemplate))
 
 	f, err := os.Create(outPath)
@@ -421,8 +782,6 @@ func formatPyDoc(doc string) string {
 	return result.String()
 }
 
-var _ types.Type
-
 const goTemplate = `package main
 
 /*
@@ -433,13 +792,42 @@ const goTemplate = `package main
 import "C"
 import (
 	"{{.GoPackage}}"
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
 )
 
 {{range .Functions}}
+{{if .Iter}}
+//export goated_{{$.Name}}_{{.Name}}
+func goated_{{$.Name}}_{{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}} {{$p.CType}}{{end}}) C.ulonglong {
+	seq := {{$.Name}}.{{.GoName}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{if eq $p.GoType "string"}}C.GoString({{$p.Name}}){{else}}{{$p.GoType}}({{$p.Name}}){{end}}{{end}})
+	return C.ulonglong(newHandle(newIterState[{{.Iter.Elem.GoType}}](seq)))
+}
+{{else}}
 //export goated_{{$.Name}}_{{.Name}}
-func goated_{{$.Name}}_{{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}} {{$p.CType}}{{end}}{{if .ReturnsErr}}, errOut **C.char{{end}}) {{if len .Results}}{{if eq (len .Results) 1}}{{(index .Results 0).CType}}{{else}}({{range $i, $r := .Results}}{{if $i}}, {{end}}{{$r.CType}}{{end}}){{end}}{{else}}{{if .ReturnsErr}}C.bool{{end}}{{end}} {
+func goated_{{$.Name}}_{{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}} {{$p.CType}}{{end}}{{if .MultiReturn}}{{range $i, $r := .Results}}{{if $i}}, {{$r.Name}}Out {{outCType $r}}{{end}}{{end}}{{end}}{{if .ReturnsErr}}, errOut **C.char{{end}}) {{if len .Results}}{{(index .Results 0).CType}}{{else}}{{if .ReturnsErr}}C.bool{{end}}{{end}} {
+{{- if .MultiReturn}}
 {{- if .ReturnsErr}}
-	{{if len .Results}}result, {{end}}err := {{$.Name}}.{{.GoName}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{if eq $p.GoType "string"}}C.GoString({{$p.Name}}){{else}}{{$p.GoType}}({{$p.Name}}){{end}}{{end}})
+{{range .Params}}{{if .IsSlice}}	{{.Name}}Val, _ := {{sliceGetter .GoType}}(uint64({{.Name}}))
+{{end}}{{end}}	{{range $i, $r := .Results}}{{if $i}}, {{end}}{{$r.Name}}{{end}}, err := {{$.Name}}.{{.GoName}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{if $p.IsSlice}}{{$p.Name}}Val{{else if eq $p.GoType "string"}}C.GoString({{$p.Name}}){{else}}{{$p.GoType}}({{$p.Name}}){{end}}{{end}})
+	if err != nil {
+		*errOut = C.CString(err.Error())
+{{range $i, $r := .Results}}{{if $i}}		*{{$r.Name}}Out = {{if eq $r.GoType "string"}}nil{{else if eq $r.GoType "bool"}}false{{else}}0{{end}}
+{{end}}{{end}}		return {{if eq (index .Results 0).GoType "string"}}nil{{else if eq (index .Results 0).GoType "bool"}}false{{else}}0{{end}}
+	}
+	*errOut = nil
+{{range $i, $r := .Results}}{{if $i}}	*{{$r.Name}}Out = {{if eq $r.GoType "string"}}C.CString({{$r.Name}}){{else}}{{$r.CType}}({{$r.Name}}){{end}}
+{{end}}{{end}}	return {{if eq (index .Results 0).GoType "string"}}C.CString({{(index .Results 0).Name}}){{else}}{{(index .Results 0).CType}}({{(index .Results 0).Name}}){{end}}
+{{- else}}
+{{range .Params}}{{if .IsSlice}}	{{.Name}}Val, _ := {{sliceGetter .GoType}}(uint64({{.Name}}))
+{{end}}{{end}}	{{range $i, $r := .Results}}{{if $i}}, {{end}}{{$r.Name}}{{end}} := {{$.Name}}.{{.GoName}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{if $p.IsSlice}}{{$p.Name}}Val{{else if eq $p.GoType "string"}}C.GoString({{$p.Name}}){{else}}{{$p.GoType}}({{$p.Name}}){{end}}{{end}})
+{{range $i, $r := .Results}}{{if $i}}	*{{$r.Name}}Out = {{if eq $r.GoType "string"}}C.CString({{$r.Name}}){{else}}{{$r.CType}}({{$r.Name}}){{end}}
+{{end}}{{end}}	return {{if eq (index .Results 0).GoType "string"}}C.CString({{(index .Results 0).Name}}){{else}}{{(index .Results 0).CType}}({{(index .Results 0).Name}}){{end}}
+{{- end}}
+{{- else if .ReturnsErr}}
+{{range .Params}}{{if .IsSlice}}	{{.Name}}Val, _ := {{sliceGetter .GoType}}(uint64({{.Name}}))
+{{end}}{{end}}	{{if len .Results}}result, {{end}}err := {{$.Name}}.{{.GoName}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{if $p.IsSlice}}{{$p.Name}}Val{{else if eq $p.GoType "string"}}C.GoString({{$p.Name}}){{else}}{{$p.GoType}}({{$p.Name}}){{end}}{{end}})
 	if err != nil {
 		*errOut = C.CString(err.Error())
 		{{if len .Results}}return {{range $i, $r := .Results}}{{if $i}}, {{end}}{{if eq $r.GoType "string"}}nil{{else if eq $r.GoType "bool"}}false{{else}}0{{end}}{{end}}{{else}}return false{{end}}
@@ -447,11 +835,46 @@ func goated_{{$.Name}}_{{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{
 	*errOut = nil
 	{{if len .Results}}return {{range $i, $r := .Results}}{{if $i}}, {{end}}{{if eq $r.GoType "string"}}C.CString(result){{else}}{{$r.CType}}(result){{end}}{{end}}{{else}}return true{{end}}
 {{- else}}
-	result := {{$.Name}}.{{.GoName}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{if eq $p.GoType "string"}}C.GoString({{$p.Name}}){{else}}{{$p.GoType}}({{$p.Name}}){{end}}{{end}})
+{{range .Params}}{{if .IsSlice}}	{{.Name}}Val, _ := {{sliceGetter .GoType}}(uint64({{.Name}}))
+{{end}}{{end}}	result := {{$.Name}}.{{.GoName}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{if $p.IsSlice}}{{$p.Name}}Val{{else if eq $p.GoType "string"}}C.GoString({{$p.Name}}){{else}}{{$p.GoType}}({{$p.Name}}){{end}}{{end}})
 	{{if len .Results}}return {{range $i, $r := .Results}}{{if $i}}, {{end}}{{if eq $r.GoType "string"}}C.CString(result){{else}}{{$r.CType}}(result){{end}}{{end}}{{else}}_ = result{{end}}
 {{- end}}
 }
 {{end}}
+{{end}}
+{{range $t := .Types}}
+//export goated_{{$.Name}}_{{$t.Name}}_new
+func goated_{{$.Name}}_{{$t.Name}}_new() C.ulonglong {
+	return C.ulonglong(newHandle(new({{$.Name}}.{{$t.Name}})))
+}
+
+//export goated_{{$.Name}}_{{$t.Name}}_free
+func goated_{{$.Name}}_{{$t.Name}}_free(handle C.ulonglong) {
+	deleteHandle(uint64(handle))
+}
+{{range $t.Methods}}
+//export goated_{{$.Name}}_{{.Recv}}_{{.Name}}
+func goated_{{$.Name}}_{{.Recv}}_{{.Name}}(handle C.ulonglong{{range .Params}}, {{.Name}} {{.CType}}{{end}}{{if .ReturnsErr}}, errOut **C.char{{end}}) {{if len .Results}}{{if eq (len .Results) 1}}{{(index .Results 0).CType}}{{else}}({{range $i, $r := .Results}}{{if $i}}, {{end}}{{$r.CType}}{{end}}){{end}}{{else}}{{if .ReturnsErr}}C.bool{{end}}{{end}} {
+	recv, ok := getHandle[*{{$.Name}}.{{.Recv}}](uint64(handle))
+	if !ok {
+		{{if .ReturnsErr}}*errOut = C.CString("invalid handle")
+		{{end}}return {{if len .Results}}{{range $i, $r := .Results}}{{if $i}}, {{end}}{{if eq $r.GoType "string"}}nil{{else if eq $r.GoType "bool"}}false{{else}}0{{end}}{{end}}{{else}}{{if .ReturnsErr}}false{{end}}{{end}}
+	}
+{{- if .ReturnsErr}}
+	{{if len .Results}}result, {{end}}err := recv.{{.GoName}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{if eq $p.GoType "string"}}C.GoString({{$p.Name}}){{else}}{{$p.GoType}}({{$p.Name}}){{end}}{{end}})
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		{{if len .Results}}return {{range $i, $r := .Results}}{{if $i}}, {{end}}{{if eq $r.GoType "string"}}nil{{else if eq $r.GoType "bool"}}false{{else}}0{{end}}{{end}}{{else}}return false{{end}}
+	}
+	*errOut = nil
+	{{if len .Results}}return {{range $i, $r := .Results}}{{if $i}}, {{end}}{{if eq $r.GoType "string"}}C.CString(result){{else}}{{$r.CType}}(result){{end}}{{end}}{{else}}return true{{end}}
+{{- else}}
+	result := recv.{{.GoName}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{if eq $p.GoType "string"}}C.GoString({{$p.Name}}){{else}}{{$p.GoType}}({{$p.Name}}){{end}}{{end}})
+	{{if len .Results}}return {{range $i, $r := .Results}}{{if $i}}, {{end}}{{if eq $r.GoType "string"}}C.CString(result){{else}}{{$r.CType}}(result){{end}}{{end}}{{else}}_ = result{{end}}
+{{- end}}
+}
+{{end}}
+{{end}}
 `
 
 const pyTemplate = `"""
@@ -462,6 +885,8 @@ This module provides Python bindings for Go's {{.GoPackage}} package.
 
 from __future__ import annotations
 
+from collections.abc import Iterator
+
 from goated._core import get_lib, is_library_available
 from goated.result import Ok, Err, Result, GoError
 
@@ -469,6 +894,9 @@ __all__ = [
 {{- range .Functions}}
     "{{.Name}}",
 {{- end}}
+{{- range .Types}}
+    "{{.Name}}",
+{{- end}}
 ]
 
 
@@ -493,30 +921,193 @@ def _configure_fn(lib, name: str, argtypes: list, restype):
     fn.restype = restype
     _fn_configured.add(name)
 
+
+def _build_string_slice(items: list[str]) -> int:
+    lib = get_lib()
+    _configure_fn(lib, "goated_slice_string_new", [], ctypes.c_ulonglong)
+    _configure_fn(lib, "goated_slice_string_append", [ctypes.c_ulonglong, ctypes.c_char_p], None)
+    handle = lib.goated_slice_string_new()
+    for item in items:
+        lib.goated_slice_string_append(handle, _encode(item))
+    return handle
+
+
+def _free_string_slice(handle: int) -> None:
+    lib = get_lib()
+    _configure_fn(lib, "goated_slice_string_free", [ctypes.c_ulonglong], None)
+    lib.goated_slice_string_free(handle)
+
+
+def _build_int_slice(items: list[int]) -> int:
+    lib = get_lib()
+    _configure_fn(lib, "goated_slice_int_new", [], ctypes.c_ulonglong)
+    _configure_fn(lib, "goated_slice_int_append", [ctypes.c_ulonglong, ctypes.c_longlong], None)
+    handle = lib.goated_slice_int_new()
+    for item in items:
+        lib.goated_slice_int_append(handle, item)
+    return handle
+
+
+def _free_int_slice(handle: int) -> None:
+    lib = get_lib()
+    _configure_fn(lib, "goated_slice_int_free", [ctypes.c_ulonglong], None)
+    lib.goated_slice_int_free(handle)
+
+
+def _build_byte_slice(data: bytes) -> int:
+    lib = get_lib()
+    _configure_fn(lib, "goated_slice_byte_from_bytes", [ctypes.c_char_p, ctypes.c_longlong], ctypes.c_ulonglong)
+    return lib.goated_slice_byte_from_bytes(data, len(data))
+
+
+def _free_byte_slice(handle: int) -> None:
+    lib = get_lib()
+    _configure_fn(lib, "goated_slice_byte_free", [ctypes.c_ulonglong], None)
+    lib.goated_slice_byte_free(handle)
+
 {{range .Functions}}
+{{if .Iter}}
+
+class _{{.Name}}Iter:
+    def __init__(self, handle: int):
+        self._handle = handle
+        self._closed = False
+
+    def __iter__(self) -> "_{{.Name}}Iter":
+        return self
+
+    def __next__(self) -> {{.Iter.Elem.PyType}}:
+        if self._closed:
+            raise StopIteration
+
+        lib = get_lib()
+        _configure_fn(lib, "goated_iter_{{.Iter.Elem.GoType}}_next", [ctypes.c_ulonglong, ctypes.POINTER(ctypes.c_bool)], {{pyIterRestype .Iter.Elem.GoType}})
+        ok_out = ctypes.c_bool()
+        result = lib.goated_iter_{{.Iter.Elem.GoType}}_next(self._handle, ctypes.byref(ok_out))
+        if not ok_out.value:
+            self.close()
+            raise StopIteration
+        return {{pyIterDecode .Iter.Elem.GoType "result"}}
+
+    def close(self) -> None:
+        if self._closed:
+            return
+        self._closed = True
+        if not is_library_available():
+            return
+        lib = get_lib()
+        _configure_fn(lib, "goated_iter_{{.Iter.Elem.GoType}}_close", [ctypes.c_ulonglong], None)
+        lib.goated_iter_{{.Iter.Elem.GoType}}_close(self._handle)
+
+    def __del__(self):
+        self.close()
+
 
-def {{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}}: {{$p.PyType}}{{end}}){{if .ReturnsErr}} -> Result[{{if len .Results}}{{(index .Results 0).PyType}}{{else}}None{{end}}, GoError]{{else}}{{if len .Results}} -> {{(index .Results 0).PyType}}{{end}}{{end}}:
+def {{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}}: {{$p.PyType}}{{end}}) -> Iterator[{{.Iter.Elem.PyType}}]:
     {{pyDoc .Doc}}
+    if not is_library_available():
+        raise NotImplementedError("Go library not available")
+
+    lib = get_lib()
+    _configure_fn(lib, "goated_{{$.Name}}_{{.Name}}", [{{range .Params}}ctypes.c_char_p, {{end}}], ctypes.c_ulonglong)
+    handle = lib.goated_{{$.Name}}_{{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{if eq $p.GoType "string"}}_encode({{$p.Name}}){{else}}{{$p.Name}}{{end}}{{end}})
+    return _{{.Name}}Iter(handle)
+{{else}}
+
+def {{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}}: {{$p.PyType}}{{end}}){{if .MultiReturn}}{{if .ReturnsErr}} -> Result[tuple[{{range $i, $r := .Results}}{{if $i}}, {{end}}{{$r.PyType}}{{end}}], GoError]{{else}} -> tuple[{{range $i, $r := .Results}}{{if $i}}, {{end}}{{$r.PyType}}{{end}}]{{end}}{{else}}{{if .ReturnsErr}} -> Result[{{if len .Results}}{{(index .Results 0).PyType}}{{else}}None{{end}}, GoError]{{else}}{{if len .Results}} -> {{(index .Results 0).PyType}}{{end}}{{end}}{{end}}:
+    {{pyDoc .Doc}}
+{{- if .MultiReturn}}
+    if not is_library_available():
 {{- if .ReturnsErr}}
+        raise NotImplementedError("Go library not available")
+{{- else}}
+        return ({{range $i, $r := .Results}}{{if $i}}, {{end}}{{if eq $r.GoType "string"}}""{{else if eq $r.GoType "bool"}}False{{else}}0{{end}}{{end}})
+{{- end}}
+
+    lib = get_lib()
+{{range .Params}}{{if .IsSlice}}    {{.Name}}_h = _build_{{sliceElemName .GoType}}_slice({{.Name}})
+{{end}}{{end}}{{range $i, $r := .Results}}{{if $i}}    {{$r.Name}}_out = {{pyOutCtor $r.GoType}}
+{{end}}{{end}}    _configure_fn(lib, "goated_{{$.Name}}_{{.Name}}", [{{range .Params}}{{if .IsSlice}}ctypes.c_ulonglong{{else}}ctypes.c_char_p{{end}}, {{end}}{{range $i, $r := .Results}}{{if $i}}{{pyOutPtr $r.GoType}}, {{end}}{{end}}{{if .ReturnsErr}}ctypes.POINTER(ctypes.c_char_p){{end}}], {{pyCRestype (index .Results 0).GoType}})
+{{- if .ReturnsErr}}
+    err_out = ctypes.c_char_p()
+{{- end}}
+    result = lib.goated_{{$.Name}}_{{.Name}}({{range .Params}}{{if .IsSlice}}{{.Name}}_h{{else if eq .GoType "string"}}_encode({{.Name}}){{else}}{{.Name}}{{end}}, {{end}}{{range $i, $r := .Results}}{{if $i}}ctypes.byref({{$r.Name}}_out), {{end}}{{end}}{{if .ReturnsErr}}ctypes.byref(err_out){{end}})
+{{range .Params}}{{if .IsSlice}}    _free_{{sliceElemName .GoType}}_slice({{.Name}}_h)
+{{end}}{{end}}{{- if .ReturnsErr}}
+
+    if err_out.value:
+        return Err(GoError(_decode(err_out.value)))
+    return Ok(({{if eq (index .Results 0).GoType "string"}}_decode(result){{else}}result{{end}}{{range $i, $r := .Results}}{{if $i}}, {{pyOutDecode $r.GoType (print $r.Name "_out")}}{{end}}{{end}}))
+{{- else}}
+    return ({{if eq (index .Results 0).GoType "string"}}_decode(result){{else}}result{{end}}{{range $i, $r := .Results}}{{if $i}}, {{pyOutDecode $r.GoType (print $r.Name "_out")}}{{end}}{{end}})
+{{- end}}
+{{- else if .ReturnsErr}}
     if not is_library_available():
         raise NotImplementedError("Go library not available")
-    
+
     lib = get_lib()
-    _configure_fn(lib, "goated_{{$.Name}}_{{.Name}}", [{{range $i, $p := .Params}}{{if $i}}, {{end}}ctypes.c_char_p{{end}}, ctypes.POINTER(ctypes.c_char_p)], {{if len .Results}}{{if eq (index .Results 0).GoType "string"}}ctypes.c_char_p{{else if eq (index .Results 0).GoType "bool"}}ctypes.c_bool{{else}}ctypes.c_longlong{{end}}{{else}}ctypes.c_bool{{end}})
+{{range .Params}}{{if .IsSlice}}    {{.Name}}_h = _build_{{sliceElemName .GoType}}_slice({{.Name}})
+{{end}}{{end}}    _configure_fn(lib, "goated_{{$.Name}}_{{.Name}}", [{{range $i, $p := .Params}}{{if $i}}, {{end}}{{if $p.IsSlice}}ctypes.c_ulonglong{{else}}ctypes.c_char_p{{end}}{{end}}, ctypes.POINTER(ctypes.c_char_p)], {{if len .Results}}{{if eq (index .Results 0).GoType "string"}}ctypes.c_char_p{{else if eq (index .Results 0).GoType "bool"}}ctypes.c_bool{{else}}ctypes.c_longlong{{end}}{{else}}ctypes.c_bool{{end}})
     err_out = ctypes.c_char_p()
-    result = lib.goated_{{$.Name}}_{{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{if eq $p.GoType "string"}}_encode({{$p.Name}}){{else}}{{$p.Name}}{{end}}{{end}}, ctypes.byref(err_out))
-    
+    result = lib.goated_{{$.Name}}_{{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{if $p.IsSlice}}{{$p.Name}}_h{{else if eq $p.GoType "string"}}_encode({{$p.Name}}){{else}}{{$p.Name}}{{end}}{{end}}, ctypes.byref(err_out))
+{{range .Params}}{{if .IsSlice}}    _free_{{sliceElemName .GoType}}_slice({{.Name}}_h)
+{{end}}{{end}}
     if err_out.value:
         return Err(GoError(_decode(err_out.value)))
     {{if len .Results}}return Ok({{if eq (index .Results 0).GoType "string"}}_decode(result){{else}}result{{end}}){{else}}return Ok(None){{end}}
 {{- else}}
     if not is_library_available():
         {{if eq (len .Results) 0}}return{{else}}{{if eq (index .Results 0).GoType "string"}}return ""{{else if eq (index .Results 0).GoType "bool"}}return False{{else if eq (index .Results 0).GoType "int"}}return 0{{else}}return None{{end}}{{end}}
-    
+
     lib = get_lib()
-    _configure_fn(lib, "goated_{{$.Name}}_{{.Name}}", [{{range $i, $p := .Params}}{{if $i}}, {{end}}ctypes.c_char_p{{end}}], {{if len .Results}}{{if eq (index .Results 0).GoType "string"}}ctypes.c_char_p{{else if eq (index .Results 0).GoType "bool"}}ctypes.c_bool{{else}}ctypes.c_longlong{{end}}{{else}}None{{end}})
-    result = lib.goated_{{$.Name}}_{{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{if eq $p.GoType "string"}}_encode({{$p.Name}}){{else}}{{$p.Name}}{{end}}{{end}})
-    {{if len .Results}}return {{if eq (index .Results 0).GoType "string"}}_decode(result){{else if eq (index .Results 0).GoType "bool"}}bool(result){{else}}result{{end}}{{end}}
+{{range .Params}}{{if .IsSlice}}    {{.Name}}_h = _build_{{sliceElemName .GoType}}_slice({{.Name}})
+{{end}}{{end}}    _configure_fn(lib, "goated_{{$.Name}}_{{.Name}}", [{{range $i, $p := .Params}}{{if $i}}, {{end}}{{if $p.IsSlice}}ctypes.c_ulonglong{{else}}ctypes.c_char_p{{end}}{{end}}], {{if len .Results}}{{if eq (index .Results 0).GoType "string"}}ctypes.c_char_p{{else if eq (index .Results 0).GoType "bool"}}ctypes.c_bool{{else}}ctypes.c_longlong{{end}}{{else}}None{{end}})
+    result = lib.goated_{{$.Name}}_{{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{if $p.IsSlice}}{{$p.Name}}_h{{else if eq $p.GoType "string"}}_encode({{$p.Name}}){{else}}{{$p.Name}}{{end}}{{end}})
+{{range .Params}}{{if .IsSlice}}    _free_{{sliceElemName .GoType}}_slice({{.Name}}_h)
+{{end}}{{end}}    {{if len .Results}}return {{if eq (index .Results 0).GoType "string"}}_decode(result){{else if eq (index .Results 0).GoType "bool"}}bool(result){{else}}result{{end}}{{end}}
+{{- end}}
+{{end}}
+{{end}}
+{{range .Types}}
+
+class {{.Name}}:
+    def __init__(self):
+        if not is_library_available():
+            raise NotImplementedError("Go library not available")
+        lib = get_lib()
+        _configure_fn(lib, "goated_{{$.Name}}_{{.Name}}_new", [], ctypes.c_ulonglong)
+        self._handle = lib.goated_{{$.Name}}_{{.Name}}_new()
+
+    def __del__(self):
+        if not is_library_available():
+            return
+        lib = get_lib()
+        _configure_fn(lib, "goated_{{$.Name}}_{{.Name}}_free", [ctypes.c_ulonglong], None)
+        lib.goated_{{$.Name}}_{{.Name}}_free(self._handle)
+{{range .Methods}}
+    def {{snakeCase .Name}}(self{{range $i, $p := .Params}}, {{$p.Name}}: {{$p.PyType}}{{end}}){{if .ReturnsErr}} -> Result[{{if len .Results}}{{(index .Results 0).PyType}}{{else}}None{{end}}, GoError]{{else}}{{if len .Results}} -> {{(index .Results 0).PyType}}{{end}}{{end}}:
+        {{pyDoc .Doc}}
+{{- if .ReturnsErr}}
+        if not is_library_available():
+            raise NotImplementedError("Go library not available")
+
+        lib = get_lib()
+        _configure_fn(lib, "goated_{{$.Name}}_{{.Recv}}_{{.Name}}", [ctypes.c_ulonglong{{range .Params}}, ctypes.c_char_p{{end}}, ctypes.POINTER(ctypes.c_char_p)], {{if len .Results}}{{if eq (index .Results 0).GoType "string"}}ctypes.c_char_p{{else if eq (index .Results 0).GoType "bool"}}ctypes.c_bool{{else}}ctypes.c_longlong{{end}}{{else}}ctypes.c_bool{{end}})
+        err_out = ctypes.c_char_p()
+        result = lib.goated_{{$.Name}}_{{.Recv}}_{{.Name}}(self._handle{{range $i, $p := .Params}}, {{if eq $p.GoType "string"}}_encode({{$p.Name}}){{else}}{{$p.Name}}{{end}}{{end}}, ctypes.byref(err_out))
+
+        if err_out.value:
+            return Err(GoError(_decode(err_out.value)))
+        {{if len .Results}}return Ok({{if eq (index .Results 0).GoType "string"}}_decode(result){{else}}result{{end}}){{else}}return Ok(None){{end}}
+{{- else}}
+        if not is_library_available():
+            {{if eq (len .Results) 0}}return{{else}}{{if eq (index .Results 0).GoType "string"}}return ""{{else if eq (index .Results 0).GoType "bool"}}return False{{else if eq (index .Results 0).GoType "int"}}return 0{{else}}return None{{end}}{{end}}
+
+        lib = get_lib()
+        _configure_fn(lib, "goated_{{$.Name}}_{{.Recv}}_{{.Name}}", [ctypes.c_ulonglong{{range .Params}}, ctypes.c_char_p{{end}}], {{if len .Results}}{{if eq (index .Results 0).GoType "string"}}ctypes.c_char_p{{else if eq (index .Results 0).GoType "bool"}}ctypes.c_bool{{else}}ctypes.c_longlong{{end}}{{else}}None{{end}})
+        result = lib.goated_{{$.Name}}_{{.Recv}}_{{.Name}}(self._handle{{range $i, $p := .Params}}, {{if eq $p.GoType "string"}}_encode({{$p.Name}}){{else}}{{$p.Name}}{{end}}{{end}})
+        {{if len .Results}}return {{if eq (index .Results 0).GoType "string"}}_decode(result){{else if eq (index .Results 0).GoType "bool"}}bool(result){{else}}result{{end}}{{end}}
 {{- end}}
 {{end}}
+{{end}}
 `
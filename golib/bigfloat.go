@@ -0,0 +1,184 @@
+package main
+
+/*
+#include <stdlib.h>
+#include <stdint.h>
+#include <stdbool.h>
+*/
+import "C"
+import (
+	"math/big"
+	"sync/atomic"
+)
+
+// bigfloatHandle mirrors bigintHandle's refcounting scheme for *big.Float.
+type bigfloatHandle struct {
+	v   *big.Float
+	ref int32
+}
+
+func newBigfloatHandle(v *big.Float) uint64 {
+	return newHandle(&bigfloatHandle{v: v, ref: 1})
+}
+
+func getBigfloatHandle(h uint64) (*bigfloatHandle, bool) {
+	return getHandle[*bigfloatHandle](h)
+}
+
+// bigfloatBinOp is bigintBinOp's counterpart for *big.Float. Unlike
+// *big.Int, *big.Float's arithmetic methods never return nil; besides an
+// invalid handle, the one failure is an operation whose result would be NaN
+// (+Inf plus -Inf, zero times Inf, Inf over Inf), for which *big.Float
+// panics with big.ErrNaN. That panic is recovered here and reported through
+// errOut like any other failure.
+func bigfloatBinOp(aH, bH C.ulonglong, errOut **C.char, op func(z, x, y *big.Float) *big.Float) (result C.ulonglong) {
+	a, ok1 := getBigfloatHandle(uint64(aH))
+	b, ok2 := getBigfloatHandle(uint64(bH))
+	if !ok1 || !ok2 {
+		*errOut = C.CString("invalid handle")
+		return 0
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			nan, ok := r.(big.ErrNaN)
+			if !ok {
+				panic(r)
+			}
+			*errOut = C.CString(nan.Error())
+			result = 0
+		}
+	}()
+	z := new(big.Float).SetPrec(a.v.Prec())
+	op(z, a.v, b.v)
+	*errOut = nil
+	return C.ulonglong(newBigfloatHandle(z))
+}
+
+//export goated_bigfloat_New
+func goated_bigfloat_New(f C.double) C.ulonglong {
+	return C.ulonglong(newBigfloatHandle(big.NewFloat(float64(f))))
+}
+
+//export goated_bigfloat_SetString
+func goated_bigfloat_SetString(s *C.char, errOut **C.char) C.ulonglong {
+	f, ok := new(big.Float).SetString(C.GoString(s))
+	if !ok {
+		*errOut = C.CString("invalid number")
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newBigfloatHandle(f))
+}
+
+//export goated_bigfloat_String
+func goated_bigfloat_String(handle C.ulonglong) *C.char {
+	a, ok := getBigfloatHandle(uint64(handle))
+	if !ok {
+		return nil
+	}
+	return C.CString(a.v.String())
+}
+
+//export goated_bigfloat_Add
+func goated_bigfloat_Add(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	return bigfloatBinOp(a, b, errOut, (*big.Float).Add)
+}
+
+//export goated_bigfloat_Sub
+func goated_bigfloat_Sub(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	return bigfloatBinOp(a, b, errOut, (*big.Float).Sub)
+}
+
+//export goated_bigfloat_Mul
+func goated_bigfloat_Mul(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	return bigfloatBinOp(a, b, errOut, (*big.Float).Mul)
+}
+
+//export goated_bigfloat_Quo
+func goated_bigfloat_Quo(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	bv, ok := getBigfloatHandle(uint64(b))
+	if ok && bv.v.Sign() == 0 {
+		*errOut = C.CString("division by zero")
+		return 0
+	}
+	return bigfloatBinOp(a, b, errOut, (*big.Float).Quo)
+}
+
+//export goated_bigfloat_Cmp
+func goated_bigfloat_Cmp(a, b C.ulonglong, errOut **C.char) C.int {
+	x, ok1 := getBigfloatHandle(uint64(a))
+	y, ok2 := getBigfloatHandle(uint64(b))
+	if !ok1 || !ok2 {
+		*errOut = C.CString("invalid handle")
+		return 0
+	}
+	*errOut = nil
+	return C.int(x.v.Cmp(y.v))
+}
+
+// goated_bigfloat_SetPrec/_Prec and _SetMode/_Mode expose the precision (in
+// mantissa bits) and rounding mode that *big.Float carries per-value, since
+// unlike *big.Int there's no single natural precision for an arbitrary
+// computation - callers need to dial it in themselves. Mode values match
+// big.RoundingMode's own iota ordering (ToNearestEven, ToNearestAway,
+// ToZero, AwayFromZero, ToNegativeInf, ToPositiveInf).
+//
+//export goated_bigfloat_SetPrec
+func goated_bigfloat_SetPrec(handle C.ulonglong, prec C.uint) {
+	a, ok := getBigfloatHandle(uint64(handle))
+	if !ok {
+		return
+	}
+	a.v.SetPrec(uint(prec))
+}
+
+//export goated_bigfloat_Prec
+func goated_bigfloat_Prec(handle C.ulonglong) C.uint {
+	a, ok := getBigfloatHandle(uint64(handle))
+	if !ok {
+		return 0
+	}
+	return C.uint(a.v.Prec())
+}
+
+//export goated_bigfloat_SetMode
+func goated_bigfloat_SetMode(handle C.ulonglong, mode C.int) {
+	a, ok := getBigfloatHandle(uint64(handle))
+	if !ok {
+		return
+	}
+	a.v.SetMode(big.RoundingMode(mode))
+}
+
+//export goated_bigfloat_Mode
+func goated_bigfloat_Mode(handle C.ulonglong) C.int {
+	a, ok := getBigfloatHandle(uint64(handle))
+	if !ok {
+		return 0
+	}
+	return C.int(a.v.Mode())
+}
+
+// goated_bigfloat_Dup/_Free follow goated_bigint_Dup/_Free's refcounting
+// contract exactly - see bigint.go for the rationale.
+//
+//export goated_bigfloat_Dup
+func goated_bigfloat_Dup(handle C.ulonglong) C.ulonglong {
+	a, ok := getBigfloatHandle(uint64(handle))
+	if !ok {
+		return 0
+	}
+	atomic.AddInt32(&a.ref, 1)
+	return handle
+}
+
+//export goated_bigfloat_Free
+func goated_bigfloat_Free(handle C.ulonglong) {
+	a, ok := getBigfloatHandle(uint64(handle))
+	if !ok {
+		return
+	}
+	if atomic.AddInt32(&a.ref, -1) <= 0 {
+		deleteHandle(uint64(handle))
+	}
+}
@@ -0,0 +1,295 @@
+package main
+
+/*
+#include <stdlib.h>
+#include <stdint.h>
+#include <stdbool.h>
+*/
+import "C"
+import (
+	"math/big"
+	"sync/atomic"
+	"unsafe"
+)
+
+// bigintHandle adds a reference count on top of the *big.Int stored in the
+// shared handle table, so goated_bigint_Dup can hand out cheap aliases
+// instead of deep-copying the number, and goated_bigint_Free only tears
+// down the handle once the last reference is gone. The count is manipulated
+// with atomics rather than a mutex since it never needs to be consistent
+// with the *big.Int value itself; concurrent Dup and Free calls only need
+// their increments and decrements not to be lost.
+type bigintHandle struct {
+	v   *big.Int
+	ref int32
+}
+
+func newBigintHandle(v *big.Int) uint64 {
+	return newHandle(&bigintHandle{v: v, ref: 1})
+}
+
+func getBigintHandle(h uint64) (*bigintHandle, bool) {
+	return getHandle[*bigintHandle](h)
+}
+
+// bigintBinOp runs a two-operand *big.Int method (Add, Sub, Mod, ...)
+// against the handles at aH/bH and hands back a new refcounted handle for
+// the result, reporting invalid handles or a nil op result (e.g.
+// ModInverse when no inverse exists) through errOut.
+func bigintBinOp(aH, bH C.ulonglong, errOut **C.char, op func(z, x, y *big.Int) *big.Int) C.ulonglong {
+	a, ok1 := getBigintHandle(uint64(aH))
+	b, ok2 := getBigintHandle(uint64(bH))
+	if !ok1 || !ok2 {
+		*errOut = C.CString("invalid handle")
+		return 0
+	}
+	z := new(big.Int)
+	if op(z, a.v, b.v) == nil {
+		*errOut = C.CString("operation has no result")
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newBigintHandle(z))
+}
+
+// bigintUnOp is bigintBinOp's one-operand counterpart, for ops like Neg/Abs
+// that never fail once the handle itself resolves.
+func bigintUnOp(aH C.ulonglong, errOut **C.char, op func(z, x *big.Int) *big.Int) C.ulonglong {
+	a, ok := getBigintHandle(uint64(aH))
+	if !ok {
+		*errOut = C.CString("invalid handle")
+		return 0
+	}
+	z := new(big.Int)
+	op(z, a.v)
+	*errOut = nil
+	return C.ulonglong(newBigintHandle(z))
+}
+
+//export goated_bigint_New
+func goated_bigint_New(n C.longlong) C.ulonglong {
+	return C.ulonglong(newBigintHandle(big.NewInt(int64(n))))
+}
+
+//export goated_bigint_SetString
+func goated_bigint_SetString(s *C.char, base C.int, errOut **C.char) C.ulonglong {
+	n, ok := new(big.Int).SetString(C.GoString(s), int(base))
+	if !ok {
+		*errOut = C.CString("invalid number for base")
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newBigintHandle(n))
+}
+
+//export goated_bigint_SetBytes
+func goated_bigint_SetBytes(buf *C.char, bufLen C.longlong) C.ulonglong {
+	n := new(big.Int).SetBytes(C.GoBytes(unsafe.Pointer(buf), C.int(bufLen)))
+	return C.ulonglong(newBigintHandle(n))
+}
+
+//export goated_bigint_Bytes
+func goated_bigint_Bytes(handle C.ulonglong, outLen *C.longlong) *C.char {
+	a, ok := getBigintHandle(uint64(handle))
+	if !ok {
+		*outLen = 0
+		return nil
+	}
+	b := a.v.Bytes()
+	*outLen = C.longlong(len(b))
+	return (*C.char)(C.CBytes(b))
+}
+
+//export goated_bigint_String
+func goated_bigint_String(handle C.ulonglong) *C.char {
+	a, ok := getBigintHandle(uint64(handle))
+	if !ok {
+		return nil
+	}
+	return C.CString(a.v.String())
+}
+
+//export goated_bigint_Add
+func goated_bigint_Add(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	return bigintBinOp(a, b, errOut, (*big.Int).Add)
+}
+
+//export goated_bigint_Sub
+func goated_bigint_Sub(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	return bigintBinOp(a, b, errOut, (*big.Int).Sub)
+}
+
+//export goated_bigint_Mul
+func goated_bigint_Mul(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	return bigintBinOp(a, b, errOut, (*big.Int).Mul)
+}
+
+//export goated_bigint_Quo
+func goated_bigint_Quo(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	bv, ok := getBigintHandle(uint64(b))
+	if ok && bv.v.Sign() == 0 {
+		*errOut = C.CString("division by zero")
+		return 0
+	}
+	return bigintBinOp(a, b, errOut, (*big.Int).Quo)
+}
+
+//export goated_bigint_Mod
+func goated_bigint_Mod(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	bv, ok := getBigintHandle(uint64(b))
+	if ok && bv.v.Sign() == 0 {
+		*errOut = C.CString("division by zero")
+		return 0
+	}
+	return bigintBinOp(a, b, errOut, (*big.Int).Mod)
+}
+
+//export goated_bigint_And
+func goated_bigint_And(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	return bigintBinOp(a, b, errOut, (*big.Int).And)
+}
+
+//export goated_bigint_Or
+func goated_bigint_Or(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	return bigintBinOp(a, b, errOut, (*big.Int).Or)
+}
+
+//export goated_bigint_Xor
+func goated_bigint_Xor(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	return bigintBinOp(a, b, errOut, (*big.Int).Xor)
+}
+
+//export goated_bigint_Neg
+func goated_bigint_Neg(a C.ulonglong, errOut **C.char) C.ulonglong {
+	return bigintUnOp(a, errOut, (*big.Int).Neg)
+}
+
+//export goated_bigint_Abs
+func goated_bigint_Abs(a C.ulonglong, errOut **C.char) C.ulonglong {
+	return bigintUnOp(a, errOut, (*big.Int).Abs)
+}
+
+//export goated_bigint_Exp
+func goated_bigint_Exp(xH, yH, mH C.ulonglong, errOut **C.char) C.ulonglong {
+	x, ok := getBigintHandle(uint64(xH))
+	y, okY := getBigintHandle(uint64(yH))
+	if !ok || !okY {
+		*errOut = C.CString("invalid handle")
+		return 0
+	}
+	var m *big.Int
+	if mH != 0 {
+		mv, okM := getBigintHandle(uint64(mH))
+		if !okM {
+			*errOut = C.CString("invalid handle")
+			return 0
+		}
+		m = mv.v
+	}
+	*errOut = nil
+	return C.ulonglong(newBigintHandle(new(big.Int).Exp(x.v, y.v, m)))
+}
+
+//export goated_bigint_Lsh
+func goated_bigint_Lsh(a C.ulonglong, n C.uint, errOut **C.char) C.ulonglong {
+	x, ok := getBigintHandle(uint64(a))
+	if !ok {
+		*errOut = C.CString("invalid handle")
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newBigintHandle(new(big.Int).Lsh(x.v, uint(n))))
+}
+
+//export goated_bigint_Rsh
+func goated_bigint_Rsh(a C.ulonglong, n C.uint, errOut **C.char) C.ulonglong {
+	x, ok := getBigintHandle(uint64(a))
+	if !ok {
+		*errOut = C.CString("invalid handle")
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newBigintHandle(new(big.Int).Rsh(x.v, uint(n))))
+}
+
+//export goated_bigint_BitLen
+func goated_bigint_BitLen(handle C.ulonglong, errOut **C.char) C.longlong {
+	a, ok := getBigintHandle(uint64(handle))
+	if !ok {
+		*errOut = C.CString("invalid handle")
+		return 0
+	}
+	*errOut = nil
+	return C.longlong(a.v.BitLen())
+}
+
+//export goated_bigint_Text
+func goated_bigint_Text(handle C.ulonglong, base C.int) *C.char {
+	a, ok := getBigintHandle(uint64(handle))
+	if !ok {
+		return nil
+	}
+	return C.CString(a.v.Text(int(base)))
+}
+
+//export goated_bigint_GCD
+func goated_bigint_GCD(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	return bigintBinOp(a, b, errOut, func(z, x, y *big.Int) *big.Int {
+		return z.GCD(nil, nil, x, y)
+	})
+}
+
+//export goated_bigint_ModInverse
+func goated_bigint_ModInverse(a, n C.ulonglong, errOut **C.char) C.ulonglong {
+	return bigintBinOp(a, n, errOut, func(z, x, y *big.Int) *big.Int {
+		return z.ModInverse(x, y)
+	})
+}
+
+//export goated_bigint_Cmp
+func goated_bigint_Cmp(a, b C.ulonglong, errOut **C.char) C.int {
+	x, ok1 := getBigintHandle(uint64(a))
+	y, ok2 := getBigintHandle(uint64(b))
+	if !ok1 || !ok2 {
+		*errOut = C.CString("invalid handle")
+		return 0
+	}
+	*errOut = nil
+	return C.int(x.v.Cmp(y.v))
+}
+
+//export goated_bigint_ProbablyPrime
+func goated_bigint_ProbablyPrime(handle C.ulonglong, reps C.int, errOut **C.char) C.bool {
+	a, ok := getBigintHandle(uint64(handle))
+	if !ok {
+		*errOut = C.CString("invalid handle")
+		return false
+	}
+	*errOut = nil
+	return C.bool(a.v.ProbablyPrime(int(reps)))
+}
+
+// goated_bigint_Dup bumps the refcount and hands back the same handle id,
+// so duplicating a bigint for a second owner costs one atomic add instead
+// of allocating and copying the underlying *big.Int.
+//
+//export goated_bigint_Dup
+func goated_bigint_Dup(handle C.ulonglong) C.ulonglong {
+	a, ok := getBigintHandle(uint64(handle))
+	if !ok {
+		return 0
+	}
+	atomic.AddInt32(&a.ref, 1)
+	return handle
+}
+
+//export goated_bigint_Free
+func goated_bigint_Free(handle C.ulonglong) {
+	a, ok := getBigintHandle(uint64(handle))
+	if !ok {
+		return
+	}
+	if atomic.AddInt32(&a.ref, -1) <= 0 {
+		deleteHandle(uint64(handle))
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+/*
+#include <stdlib.h>
+#include <stdint.h>
+#include <stdbool.h>
+*/
+import "C"
+import (
+	"math/big"
+	"sync/atomic"
+)
+
+// bigratHandle mirrors bigintHandle's refcounting scheme for *big.Rat.
+type bigratHandle struct {
+	v   *big.Rat
+	ref int32
+}
+
+func newBigratHandle(v *big.Rat) uint64 {
+	return newHandle(&bigratHandle{v: v, ref: 1})
+}
+
+func getBigratHandle(h uint64) (*bigratHandle, bool) {
+	return getHandle[*bigratHandle](h)
+}
+
+// bigratBinOp is bigintBinOp's counterpart for *big.Rat; like *big.Float,
+// *big.Rat's arithmetic methods never return nil, so the only failure mode
+// here is an invalid handle.
+func bigratBinOp(aH, bH C.ulonglong, errOut **C.char, op func(z, x, y *big.Rat) *big.Rat) C.ulonglong {
+	a, ok1 := getBigratHandle(uint64(aH))
+	b, ok2 := getBigratHandle(uint64(bH))
+	if !ok1 || !ok2 {
+		*errOut = C.CString("invalid handle")
+		return 0
+	}
+	z := new(big.Rat)
+	op(z, a.v, b.v)
+	*errOut = nil
+	return C.ulonglong(newBigratHandle(z))
+}
+
+//export goated_bigrat_New
+func goated_bigrat_New(num, denom C.longlong, errOut **C.char) C.ulonglong {
+	if denom == 0 {
+		*errOut = C.CString("division by zero")
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newBigratHandle(big.NewRat(int64(num), int64(denom))))
+}
+
+//export goated_bigrat_SetString
+func goated_bigrat_SetString(s *C.char, errOut **C.char) C.ulonglong {
+	r, ok := new(big.Rat).SetString(C.GoString(s))
+	if !ok {
+		*errOut = C.CString("invalid number")
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newBigratHandle(r))
+}
+
+//export goated_bigrat_String
+func goated_bigrat_String(handle C.ulonglong) *C.char {
+	a, ok := getBigratHandle(uint64(handle))
+	if !ok {
+		return nil
+	}
+	return C.CString(a.v.String())
+}
+
+//export goated_bigrat_Add
+func goated_bigrat_Add(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	return bigratBinOp(a, b, errOut, (*big.Rat).Add)
+}
+
+//export goated_bigrat_Sub
+func goated_bigrat_Sub(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	return bigratBinOp(a, b, errOut, (*big.Rat).Sub)
+}
+
+//export goated_bigrat_Mul
+func goated_bigrat_Mul(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	return bigratBinOp(a, b, errOut, (*big.Rat).Mul)
+}
+
+//export goated_bigrat_Quo
+func goated_bigrat_Quo(a, b C.ulonglong, errOut **C.char) C.ulonglong {
+	bv, ok := getBigratHandle(uint64(b))
+	if ok && bv.v.Sign() == 0 {
+		*errOut = C.CString("division by zero")
+		return 0
+	}
+	return bigratBinOp(a, b, errOut, (*big.Rat).Quo)
+}
+
+//export goated_bigrat_Cmp
+func goated_bigrat_Cmp(a, b C.ulonglong, errOut **C.char) C.int {
+	x, ok1 := getBigratHandle(uint64(a))
+	y, ok2 := getBigratHandle(uint64(b))
+	if !ok1 || !ok2 {
+		*errOut = C.CString("invalid handle")
+		return 0
+	}
+	*errOut = nil
+	return C.int(x.v.Cmp(y.v))
+}
+
+// goated_bigrat_Dup/_Free follow goated_bigint_Dup/_Free's refcounting
+// contract exactly - see bigint.go for the rationale.
+//
+//export goated_bigrat_Dup
+func goated_bigrat_Dup(handle C.ulonglong) C.ulonglong {
+	a, ok := getBigratHandle(uint64(handle))
+	if !ok {
+		return 0
+	}
+	atomic.AddInt32(&a.ref, 1)
+	return handle
+}
+
+//export goated_bigrat_Free
+func goated_bigrat_Free(handle C.ulonglong) {
+	a, ok := getBigratHandle(uint64(handle))
+	if !ok {
+		return
+	}
+	if atomic.AddInt32(&a.ref, -1) <= 0 {
+		deleteHandle(uint64(handle))
+	}
+}
@@ -0,0 +1,472 @@
+package main
+
+/*
+#include <stdlib.h>
+#include <stdint.h>
+#include <stdbool.h>
+*/
+import "C"
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"sync"
+	"unsafe"
+)
+
+// compressWriterState buffers a compressor's output in memory so
+// goated_<fmt>_ReadCompressed can pull it out in caller-sized chunks
+// instead of the whole payload having to be compressed up front.
+type compressWriterState struct {
+	buf *bytes.Buffer
+	w   io.WriteCloser
+}
+
+func newCompressWriterState(w io.WriteCloser, buf *bytes.Buffer) *compressWriterState {
+	return &compressWriterState{buf: buf, w: w}
+}
+
+func (st *compressWriterState) write(p []byte) (int, error) {
+	n, err := st.w.Write(p)
+	if err == nil {
+		if f, ok := st.w.(interface{ Flush() error }); ok {
+			err = f.Flush()
+		}
+	}
+	return n, err
+}
+
+func (st *compressWriterState) readCompressed(p []byte) int {
+	n, _ := st.buf.Read(p)
+	return n
+}
+
+// compressReaderState feeds push-style compressed bytes into a pull-style
+// compress/gzip, compress/zlib or compress/flate reader by routing them
+// through an io.Pipe. The pipe write happens on its own pump goroutine, not
+// inside feed: the decompressor's internal bufio reader may read ahead for
+// more than one feed() call's worth of bytes while construct() validates a
+// format header, and since the caller of feed() is the same synchronous
+// thread that would have to answer a Read() to unblock its own pw.Write,
+// feeding straight into the pipe can deadlock the caller on a single large
+// chunk. feed() instead appends to an in-memory queue and returns
+// immediately; pump drains that queue into the pipe at whatever pace the
+// decompressor actually consumes it.
+type compressReaderState struct {
+	pw    *io.PipeWriter
+	ready chan struct{}
+	done  chan struct{}
+	rc    io.ReadCloser
+	err   error
+	once  sync.Once
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  [][]byte
+	closed bool
+}
+
+func newCompressReaderState(construct func(io.Reader) (io.ReadCloser, error)) *compressReaderState {
+	pr, pw := io.Pipe()
+	st := &compressReaderState{pw: pw, ready: make(chan struct{}), done: make(chan struct{})}
+	st.cond = sync.NewCond(&st.mu)
+	go func() {
+		st.rc, st.err = construct(pr)
+		close(st.ready)
+	}()
+	go st.pump()
+	return st
+}
+
+// pump writes queued chunks into the pipe one at a time, blocking on
+// pw.Write for as long as the decompressor takes to read each one. Since
+// this runs off the caller's goroutine, feed() never has to wait on it.
+func (st *compressReaderState) pump() {
+	defer close(st.done)
+	defer st.pw.Close()
+	for {
+		st.mu.Lock()
+		for len(st.queue) == 0 && !st.closed {
+			st.cond.Wait()
+		}
+		if len(st.queue) == 0 {
+			st.mu.Unlock()
+			return
+		}
+		chunk := st.queue[0]
+		st.queue = st.queue[1:]
+		st.mu.Unlock()
+
+		if _, err := st.pw.Write(chunk); err != nil {
+			return
+		}
+	}
+}
+
+func (st *compressReaderState) feed(p []byte) (int, error) {
+	st.mu.Lock()
+	if st.closed {
+		st.mu.Unlock()
+		return 0, io.ErrClosedPipe
+	}
+	st.queue = append(st.queue, append([]byte(nil), p...))
+	st.mu.Unlock()
+	st.cond.Signal()
+	return len(p), nil
+}
+
+func (st *compressReaderState) read(p []byte) (int, error) {
+	<-st.ready
+	if st.err != nil {
+		return 0, st.err
+	}
+	return st.rc.Read(p)
+}
+
+func (st *compressReaderState) close() {
+	st.once.Do(func() {
+		st.mu.Lock()
+		st.closed = true
+		st.mu.Unlock()
+		st.cond.Signal()
+		st.pw.Close() // unblocks pump if it's mid-Write with nothing reading
+		<-st.done
+		<-st.ready
+		if st.rc != nil {
+			st.rc.Close()
+		}
+	})
+}
+
+// ---- gzip ----
+
+//export goated_gzip_NewWriter
+func goated_gzip_NewWriter(level C.int, errOut **C.char) C.ulonglong {
+	buf := &bytes.Buffer{}
+	gw, err := gzip.NewWriterLevel(buf, int(level))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newHandle(newCompressWriterState(gw, buf)))
+}
+
+//export goated_gzip_Write
+func goated_gzip_Write(handle C.ulonglong, data *C.char, dataLen C.longlong) C.longlong {
+	st, ok := getHandle[*compressWriterState](uint64(handle))
+	if !ok {
+		return -1
+	}
+	n, err := st.write(unsafe.Slice((*byte)(unsafe.Pointer(data)), int(dataLen)))
+	if err != nil {
+		return -1
+	}
+	return C.longlong(n)
+}
+
+//export goated_gzip_ReadCompressed
+func goated_gzip_ReadCompressed(handle C.ulonglong, buf *C.char, bufLen C.longlong) C.longlong {
+	st, ok := getHandle[*compressWriterState](uint64(handle))
+	if !ok {
+		return -1
+	}
+	return C.longlong(st.readCompressed(unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(bufLen))))
+}
+
+//export goated_gzip_WriterClose
+func goated_gzip_WriterClose(handle C.ulonglong) {
+	if st, ok := getHandle[*compressWriterState](uint64(handle)); ok {
+		st.w.Close()
+	}
+}
+
+//export goated_gzip_WriterFree
+func goated_gzip_WriterFree(handle C.ulonglong) {
+	if st, ok := getHandle[*compressWriterState](uint64(handle)); ok {
+		st.w.Close()
+	}
+	deleteHandle(uint64(handle))
+}
+
+//export goated_gzip_NewReader
+func goated_gzip_NewReader() C.ulonglong {
+	st := newCompressReaderState(func(r io.Reader) (io.ReadCloser, error) {
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		// A caller feeds exactly one member's worth of compressed bytes and
+		// then reads until EOF without ever signaling "no more input" ahead
+		// of time. With Multistream's default of true, Read blocks after the
+		// last member's trailer looking for a second one instead of
+		// returning io.EOF, hanging the same way feed() used to.
+		gr.Multistream(false)
+		return gr, nil
+	})
+	return C.ulonglong(newHandle(st))
+}
+
+//export goated_gzip_FeedCompressed
+func goated_gzip_FeedCompressed(handle C.ulonglong, data *C.char, dataLen C.longlong) C.longlong {
+	st, ok := getHandle[*compressReaderState](uint64(handle))
+	if !ok {
+		return -1
+	}
+	n, err := st.feed(unsafe.Slice((*byte)(unsafe.Pointer(data)), int(dataLen)))
+	if err != nil {
+		return -1
+	}
+	return C.longlong(n)
+}
+
+//export goated_gzip_Read
+func goated_gzip_Read(handle C.ulonglong, buf *C.char, bufLen C.longlong) C.longlong {
+	st, ok := getHandle[*compressReaderState](uint64(handle))
+	if !ok {
+		return -1
+	}
+	n, err := st.read(unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(bufLen)))
+	if n == 0 && err != nil {
+		return -1
+	}
+	return C.longlong(n)
+}
+
+//export goated_gzip_ReaderFree
+func goated_gzip_ReaderFree(handle C.ulonglong) {
+	if st, ok := getHandle[*compressReaderState](uint64(handle)); ok {
+		st.close()
+	}
+	deleteHandle(uint64(handle))
+}
+
+//export goated_gzip_Compress
+func goated_gzip_Compress(data *C.char, dataLen C.longlong, level C.int, outLen *C.longlong, errOut **C.char) *C.char {
+	var buf bytes.Buffer
+	gw, err := gzip.NewWriterLevel(&buf, int(level))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		*outLen = 0
+		return nil
+	}
+	if _, err := gw.Write(C.GoBytes(unsafe.Pointer(data), C.int(dataLen))); err != nil {
+		*errOut = C.CString(err.Error())
+		*outLen = 0
+		return nil
+	}
+	if err := gw.Close(); err != nil {
+		*errOut = C.CString(err.Error())
+		*outLen = 0
+		return nil
+	}
+	*errOut = nil
+	*outLen = C.longlong(buf.Len())
+	return (*C.char)(C.CBytes(buf.Bytes()))
+}
+
+//export goated_gzip_Decompress
+func goated_gzip_Decompress(data *C.char, dataLen C.longlong, outLen *C.longlong, errOut **C.char) *C.char {
+	gr, err := gzip.NewReader(bytes.NewReader(C.GoBytes(unsafe.Pointer(data), C.int(dataLen))))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		*outLen = 0
+		return nil
+	}
+	defer gr.Close()
+	result, err := io.ReadAll(gr)
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		*outLen = 0
+		return nil
+	}
+	*errOut = nil
+	*outLen = C.longlong(len(result))
+	return (*C.char)(C.CBytes(result))
+}
+
+// ---- zlib ----
+
+//export goated_zlib_NewWriter
+func goated_zlib_NewWriter(level C.int, errOut **C.char) C.ulonglong {
+	buf := &bytes.Buffer{}
+	zw, err := zlib.NewWriterLevel(buf, int(level))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newHandle(newCompressWriterState(zw, buf)))
+}
+
+//export goated_zlib_Write
+func goated_zlib_Write(handle C.ulonglong, data *C.char, dataLen C.longlong) C.longlong {
+	st, ok := getHandle[*compressWriterState](uint64(handle))
+	if !ok {
+		return -1
+	}
+	n, err := st.write(unsafe.Slice((*byte)(unsafe.Pointer(data)), int(dataLen)))
+	if err != nil {
+		return -1
+	}
+	return C.longlong(n)
+}
+
+//export goated_zlib_ReadCompressed
+func goated_zlib_ReadCompressed(handle C.ulonglong, buf *C.char, bufLen C.longlong) C.longlong {
+	st, ok := getHandle[*compressWriterState](uint64(handle))
+	if !ok {
+		return -1
+	}
+	return C.longlong(st.readCompressed(unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(bufLen))))
+}
+
+//export goated_zlib_WriterClose
+func goated_zlib_WriterClose(handle C.ulonglong) {
+	if st, ok := getHandle[*compressWriterState](uint64(handle)); ok {
+		st.w.Close()
+	}
+}
+
+//export goated_zlib_WriterFree
+func goated_zlib_WriterFree(handle C.ulonglong) {
+	if st, ok := getHandle[*compressWriterState](uint64(handle)); ok {
+		st.w.Close()
+	}
+	deleteHandle(uint64(handle))
+}
+
+//export goated_zlib_NewReader
+func goated_zlib_NewReader() C.ulonglong {
+	st := newCompressReaderState(zlib.NewReader)
+	return C.ulonglong(newHandle(st))
+}
+
+//export goated_zlib_FeedCompressed
+func goated_zlib_FeedCompressed(handle C.ulonglong, data *C.char, dataLen C.longlong) C.longlong {
+	st, ok := getHandle[*compressReaderState](uint64(handle))
+	if !ok {
+		return -1
+	}
+	n, err := st.feed(unsafe.Slice((*byte)(unsafe.Pointer(data)), int(dataLen)))
+	if err != nil {
+		return -1
+	}
+	return C.longlong(n)
+}
+
+//export goated_zlib_Read
+func goated_zlib_Read(handle C.ulonglong, buf *C.char, bufLen C.longlong) C.longlong {
+	st, ok := getHandle[*compressReaderState](uint64(handle))
+	if !ok {
+		return -1
+	}
+	n, err := st.read(unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(bufLen)))
+	if n == 0 && err != nil {
+		return -1
+	}
+	return C.longlong(n)
+}
+
+//export goated_zlib_ReaderFree
+func goated_zlib_ReaderFree(handle C.ulonglong) {
+	if st, ok := getHandle[*compressReaderState](uint64(handle)); ok {
+		st.close()
+	}
+	deleteHandle(uint64(handle))
+}
+
+// ---- flate ----
+
+//export goated_flate_NewWriter
+func goated_flate_NewWriter(level C.int, errOut **C.char) C.ulonglong {
+	buf := &bytes.Buffer{}
+	fw, err := flate.NewWriter(buf, int(level))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newHandle(newCompressWriterState(fw, buf)))
+}
+
+//export goated_flate_Write
+func goated_flate_Write(handle C.ulonglong, data *C.char, dataLen C.longlong) C.longlong {
+	st, ok := getHandle[*compressWriterState](uint64(handle))
+	if !ok {
+		return -1
+	}
+	n, err := st.write(unsafe.Slice((*byte)(unsafe.Pointer(data)), int(dataLen)))
+	if err != nil {
+		return -1
+	}
+	return C.longlong(n)
+}
+
+//export goated_flate_ReadCompressed
+func goated_flate_ReadCompressed(handle C.ulonglong, buf *C.char, bufLen C.longlong) C.longlong {
+	st, ok := getHandle[*compressWriterState](uint64(handle))
+	if !ok {
+		return -1
+	}
+	return C.longlong(st.readCompressed(unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(bufLen))))
+}
+
+//export goated_flate_WriterClose
+func goated_flate_WriterClose(handle C.ulonglong) {
+	if st, ok := getHandle[*compressWriterState](uint64(handle)); ok {
+		st.w.Close()
+	}
+}
+
+//export goated_flate_WriterFree
+func goated_flate_WriterFree(handle C.ulonglong) {
+	if st, ok := getHandle[*compressWriterState](uint64(handle)); ok {
+		st.w.Close()
+	}
+	deleteHandle(uint64(handle))
+}
+
+//export goated_flate_NewReader
+func goated_flate_NewReader() C.ulonglong {
+	st := newCompressReaderState(func(r io.Reader) (io.ReadCloser, error) {
+		return flate.NewReader(r), nil
+	})
+	return C.ulonglong(newHandle(st))
+}
+
+//export goated_flate_FeedCompressed
+func goated_flate_FeedCompressed(handle C.ulonglong, data *C.char, dataLen C.longlong) C.longlong {
+	st, ok := getHandle[*compressReaderState](uint64(handle))
+	if !ok {
+		return -1
+	}
+	n, err := st.feed(unsafe.Slice((*byte)(unsafe.Pointer(data)), int(dataLen)))
+	if err != nil {
+		return -1
+	}
+	return C.longlong(n)
+}
+
+//export goated_flate_Read
+func goated_flate_Read(handle C.ulonglong, buf *C.char, bufLen C.longlong) C.longlong {
+	st, ok := getHandle[*compressReaderState](uint64(handle))
+	if !ok {
+		return -1
+	}
+	n, err := st.read(unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(bufLen)))
+	if n == 0 && err != nil {
+		return -1
+	}
+	return C.longlong(n)
+}
+
+//export goated_flate_ReaderFree
+func goated_flate_ReaderFree(handle C.ulonglong) {
+	if st, ok := getHandle[*compressReaderState](uint64(handle)); ok {
+		st.close()
+	}
+	deleteHandle(uint64(handle))
+}
@@ -4,10 +4,25 @@ package main
 #include <stdlib.h>
 #include <stdint.h>
 #include <stdbool.h>
+
+// goated_filepath_walk_cb is invoked once per goated_filepath_WalkDir entry.
+// typ is 1 for a directory and 0 for anything else. A false return skips
+// the rest of the current directory's subtree (mapped to filepath.SkipDir
+// when the entry is itself a directory) or aborts the whole walk (mapped to
+// filepath.SkipAll when it isn't) - see goated_filepath_WalkDir below. Go
+// can't call a C function pointer directly, so the thunk routes the call
+// through this static wrapper.
+typedef bool (*goated_filepath_walk_cb)(const char* path, int typ, const char* err, void* user);
+
+static inline bool goated_filepath_call_walk_cb(goated_filepath_walk_cb cb, const char* path, int typ, const char* err, void* user) {
+	return cb(path, typ, err, user);
+}
 */
 import "C"
 import (
+	"io/fs"
 	"path/filepath"
+	"unsafe"
 )
 
 
@@ -22,24 +37,50 @@ func goated_filepath_Abs(path_ *C.char, errOut **C.char) *C.char {
 	return C.CString(result)
 }
 
+//export goated_filepath_Abs_Into
+func goated_filepath_Abs_Into(path_ *C.char, buf *C.char, bufLen C.int, errOut **C.char) C.int {
+	result, err := filepath.Abs(C.GoString(path_))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return copyIntoBuf(buf, bufLen, result)
+}
+
 //export goated_filepath_Base
 func goated_filepath_Base(path_ *C.char) *C.char {
 	result := filepath.Base(C.GoString(path_))
 	return C.CString(result)
 }
 
+//export goated_filepath_Base_Into
+func goated_filepath_Base_Into(path_ *C.char, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, filepath.Base(C.GoString(path_)))
+}
+
 //export goated_filepath_Clean
 func goated_filepath_Clean(path_ *C.char) *C.char {
 	result := filepath.Clean(C.GoString(path_))
 	return C.CString(result)
 }
 
+//export goated_filepath_Clean_Into
+func goated_filepath_Clean_Into(path_ *C.char, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, filepath.Clean(C.GoString(path_)))
+}
+
 //export goated_filepath_Dir
 func goated_filepath_Dir(path_ *C.char) *C.char {
 	result := filepath.Dir(C.GoString(path_))
 	return C.CString(result)
 }
 
+//export goated_filepath_Dir_Into
+func goated_filepath_Dir_Into(path_ *C.char, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, filepath.Dir(C.GoString(path_)))
+}
+
 //export goated_filepath_EvalSymlinks
 func goated_filepath_EvalSymlinks(path_ *C.char, errOut **C.char) *C.char {
 	result, err := filepath.EvalSymlinks(C.GoString(path_))
@@ -51,18 +92,39 @@ func goated_filepath_EvalSymlinks(path_ *C.char, errOut **C.char) *C.char {
 	return C.CString(result)
 }
 
+//export goated_filepath_EvalSymlinks_Into
+func goated_filepath_EvalSymlinks_Into(path_ *C.char, buf *C.char, bufLen C.int, errOut **C.char) C.int {
+	result, err := filepath.EvalSymlinks(C.GoString(path_))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return copyIntoBuf(buf, bufLen, result)
+}
+
 //export goated_filepath_Ext
 func goated_filepath_Ext(path_ *C.char) *C.char {
 	result := filepath.Ext(C.GoString(path_))
 	return C.CString(result)
 }
 
+//export goated_filepath_Ext_Into
+func goated_filepath_Ext_Into(path_ *C.char, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, filepath.Ext(C.GoString(path_)))
+}
+
 //export goated_filepath_FromSlash
 func goated_filepath_FromSlash(path_ *C.char) *C.char {
 	result := filepath.FromSlash(C.GoString(path_))
 	return C.CString(result)
 }
 
+//export goated_filepath_FromSlash_Into
+func goated_filepath_FromSlash_Into(path_ *C.char, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, filepath.FromSlash(C.GoString(path_)))
+}
+
 //export goated_filepath_HasPrefix
 func goated_filepath_HasPrefix(p *C.char, prefix *C.char) C.bool {
 	result := filepath.HasPrefix(C.GoString(p), C.GoString(prefix))
@@ -92,6 +154,17 @@ func goated_filepath_Localize(path_ *C.char, errOut **C.char) *C.char {
 	return C.CString(result)
 }
 
+//export goated_filepath_Localize_Into
+func goated_filepath_Localize_Into(path_ *C.char, buf *C.char, bufLen C.int, errOut **C.char) C.int {
+	result, err := filepath.Localize(C.GoString(path_))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return copyIntoBuf(buf, bufLen, result)
+}
+
 //export goated_filepath_Match
 func goated_filepath_Match(pattern *C.char, name *C.char, errOut **C.char) C.bool {
 	result, err := filepath.Match(C.GoString(pattern), C.GoString(name))
@@ -114,15 +187,159 @@ func goated_filepath_Rel(basepath *C.char, targpath *C.char, errOut **C.char) *C
 	return C.CString(result)
 }
 
+//export goated_filepath_Rel_Into
+func goated_filepath_Rel_Into(basepath *C.char, targpath *C.char, buf *C.char, bufLen C.int, errOut **C.char) C.int {
+	result, err := filepath.Rel(C.GoString(basepath), C.GoString(targpath))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return copyIntoBuf(buf, bufLen, result)
+}
+
 //export goated_filepath_ToSlash
 func goated_filepath_ToSlash(path_ *C.char) *C.char {
 	result := filepath.ToSlash(C.GoString(path_))
 	return C.CString(result)
 }
 
+//export goated_filepath_ToSlash_Into
+func goated_filepath_ToSlash_Into(path_ *C.char, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, filepath.ToSlash(C.GoString(path_)))
+}
+
 //export goated_filepath_VolumeName
 func goated_filepath_VolumeName(path_ *C.char) *C.char {
 	result := filepath.VolumeName(C.GoString(path_))
 	return C.CString(result)
 }
 
+//export goated_filepath_VolumeName_Into
+func goated_filepath_VolumeName_Into(path_ *C.char, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, filepath.VolumeName(C.GoString(path_)))
+}
+
+//export goated_filepath_Glob
+func goated_filepath_Glob(pattern *C.char, errOut **C.char) C.ulonglong {
+	matches, err := filepath.Glob(C.GoString(pattern))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newHandle(matches))
+}
+
+//export goated_filepath_SplitList
+func goated_filepath_SplitList(path_ *C.char) C.ulonglong {
+	return C.ulonglong(newHandle(filepath.SplitList(C.GoString(path_))))
+}
+
+//export goated_filepath_Split
+func goated_filepath_Split(path_ *C.char, dirOut **C.char, fileOut **C.char) {
+	dir, file := filepath.Split(C.GoString(path_))
+	*dirOut = C.CString(dir)
+	*fileOut = C.CString(file)
+}
+
+// walkDirTyp reports the goated_filepath_walk_cb typ value for a DirEntry:
+// 1 for a directory, 0 for anything else (including the nil entry WalkDir
+// hands back alongside a stat error on the root itself).
+func walkDirTyp(d fs.DirEntry) C.int {
+	if d != nil && d.IsDir() {
+		return 1
+	}
+	return 0
+}
+
+//export goated_filepath_WalkDir
+func goated_filepath_WalkDir(root *C.char, cb C.goated_filepath_walk_cb, user unsafe.Pointer, errOut **C.char) {
+	err := filepath.WalkDir(C.GoString(root), func(path string, d fs.DirEntry, err error) error {
+		typ := walkDirTyp(d)
+
+		cPath := C.CString(path)
+		defer C.free(unsafe.Pointer(cPath))
+
+		var cErr *C.char
+		if err != nil {
+			cErr = C.CString(err.Error())
+			defer C.free(unsafe.Pointer(cErr))
+		}
+
+		if bool(C.goated_filepath_call_walk_cb(cb, cPath, typ, cErr, user)) {
+			return nil
+		}
+		if typ == 1 {
+			return filepath.SkipDir
+		}
+		return filepath.SkipAll
+	})
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return
+	}
+	*errOut = nil
+}
+
+// filepathWalkEntry is what goated_filepath_WalkOpen feeds through the
+// shared iterState[T] machinery (see iter.go) for callers that can't
+// re-enter their own code safely from a Go-invoked C callback on whatever
+// thread WalkDir happens to run on.
+type filepathWalkEntry struct {
+	path string
+	typ  C.int
+	err  string
+}
+
+//export goated_filepath_WalkOpen
+func goated_filepath_WalkOpen(root *C.char) C.ulonglong {
+	rootPath := C.GoString(root)
+	st := newIterState(func(yield func(filepathWalkEntry) bool) {
+		filepath.WalkDir(rootPath, func(path string, d fs.DirEntry, err error) error {
+			typ := walkDirTyp(d)
+			errStr := ""
+			if err != nil {
+				errStr = err.Error()
+			}
+			if yield(filepathWalkEntry{path: path, typ: typ, err: errStr}) {
+				return nil
+			}
+			if typ == 1 {
+				return filepath.SkipDir
+			}
+			return filepath.SkipAll
+		})
+	})
+	return C.ulonglong(newHandle(st))
+}
+
+//export goated_filepath_WalkNext
+func goated_filepath_WalkNext(handle C.ulonglong, typOut *C.int, errOut **C.char, okOut *C.bool) *C.char {
+	st, ok := getHandle[*iterState[filepathWalkEntry]](uint64(handle))
+	if !ok {
+		*okOut = false
+		return nil
+	}
+	entry, hasNext := st.next()
+	*okOut = C.bool(hasNext)
+	if !hasNext {
+		return nil
+	}
+	*typOut = entry.typ
+	if entry.err != "" {
+		*errOut = C.CString(entry.err)
+	} else {
+		*errOut = nil
+	}
+	return C.CString(entry.path)
+}
+
+//export goated_filepath_WalkClose
+func goated_filepath_WalkClose(handle C.ulonglong) {
+	if st, ok := getHandle[*iterState[filepathWalkEntry]](uint64(handle)); ok {
+		st.close()
+	}
+	deleteHandle(uint64(handle))
+}
+
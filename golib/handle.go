@@ -1,8 +1,13 @@
 package main
 
+/*
+#include <stdlib.h>
+*/
+import "C"
 import (
 	"sync"
 	"sync/atomic"
+	"unsafe"
 )
 
 var (
@@ -33,7 +38,30 @@ func getStringSlice(h uint64) ([]string, bool) {
 	return getHandle[[]string](h)
 }
 
+// getIntSlice returns []int, not []int64, so a generated []int-shaped
+// parameter (GoType "[]int") gets back exactly the type the wrapped
+// function signature expects without a conversion at the call site.
+func getIntSlice(h uint64) ([]int, bool) {
+	return getHandle[[]int](h)
+}
+
+func getByteSlice(h uint64) ([]byte, bool) {
+	return getHandle[[]byte](h)
+}
+
 // getAny returns the object at the handle as interface{}
 func getAny(h uint64) (any, bool) {
 	return handles.Load(h)
 }
+
+// goated_free releases a pointer returned by any C.CString/C.CBytes-backed
+// export in this module (goated_strconv_Itoa, goated_filepath_Clean, ...).
+// It exists because every one of those exports hands the caller malloc'd
+// memory with no matching free in the generated surface; callers that
+// can't link cgo's own free() directly (e.g. from Python via ctypes) go
+// through this instead.
+//
+//export goated_free
+func goated_free(ptr unsafe.Pointer) {
+	C.free(ptr)
+}
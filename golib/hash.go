@@ -0,0 +1,142 @@
+package main
+
+/*
+#include <stdlib.h>
+#include <stdint.h>
+#include <stdbool.h>
+*/
+import "C"
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha3"
+	"crypto/sha512"
+	"hash"
+	"unsafe"
+)
+
+// Algorithm codes shared by goated_hash_new and goated_hmac_new.
+const (
+	hashAlgoMD5 = iota
+	hashAlgoSHA1
+	hashAlgoSHA256
+	hashAlgoSHA512
+	hashAlgoSHA3_256
+)
+
+func newHashByAlgo(algo int) hash.Hash {
+	switch algo {
+	case hashAlgoMD5:
+		return md5.New()
+	case hashAlgoSHA1:
+		return sha1.New()
+	case hashAlgoSHA256:
+		return sha256.New()
+	case hashAlgoSHA512:
+		return sha512.New()
+	case hashAlgoSHA3_256:
+		return sha3.New256()
+	default:
+		return nil
+	}
+}
+
+// goated_hash_new and the handle-taking thunks below stream data into a
+// hash.Hash incrementally, unlike goated_crypto_sha256_Sum and friends
+// which need the whole input copied into C.GoBytes up front.
+
+//export goated_hash_new
+func goated_hash_new(algo C.int) C.ulonglong {
+	h := newHashByAlgo(int(algo))
+	if h == nil {
+		return 0
+	}
+	return C.ulonglong(newHandle(h))
+}
+
+//export goated_hash_write
+func goated_hash_write(handle C.ulonglong, data *C.char, dataLen C.longlong) {
+	h, ok := getHandle[hash.Hash](uint64(handle))
+	if !ok {
+		return
+	}
+	h.Write(C.GoBytes(unsafe.Pointer(data), C.int(dataLen)))
+}
+
+//export goated_hash_sum
+func goated_hash_sum(handle C.ulonglong, outLen *C.longlong) *C.char {
+	h, ok := getHandle[hash.Hash](uint64(handle))
+	if !ok {
+		*outLen = 0
+		return nil
+	}
+	sum := h.Sum(nil)
+	*outLen = C.longlong(len(sum))
+	return (*C.char)(C.CBytes(sum))
+}
+
+//export goated_hash_reset
+func goated_hash_reset(handle C.ulonglong) {
+	h, ok := getHandle[hash.Hash](uint64(handle))
+	if !ok {
+		return
+	}
+	h.Reset()
+}
+
+//export goated_hash_size
+func goated_hash_size(handle C.ulonglong) C.longlong {
+	h, ok := getHandle[hash.Hash](uint64(handle))
+	if !ok {
+		return 0
+	}
+	return C.longlong(h.Size())
+}
+
+//export goated_hash_blocksize
+func goated_hash_blocksize(handle C.ulonglong) C.longlong {
+	h, ok := getHandle[hash.Hash](uint64(handle))
+	if !ok {
+		return 0
+	}
+	return C.longlong(h.BlockSize())
+}
+
+//export goated_hash_free
+func goated_hash_free(handle C.ulonglong) {
+	deleteHandle(uint64(handle))
+}
+
+// goated_hmac_new hands back a handle through the exact same hash.Hash
+// machinery as goated_hash_new, so goated_hash_write/_sum/_reset/_size/
+// _blocksize/_free all work on an HMAC handle unchanged.
+
+//export goated_hmac_new
+func goated_hmac_new(algo C.int, key *C.char, keyLen C.longlong) C.ulonglong {
+	var newHash func() hash.Hash
+	switch int(algo) {
+	case hashAlgoMD5:
+		newHash = md5.New
+	case hashAlgoSHA1:
+		newHash = sha1.New
+	case hashAlgoSHA256:
+		newHash = sha256.New
+	case hashAlgoSHA512:
+		newHash = sha512.New
+	case hashAlgoSHA3_256:
+		newHash = func() hash.Hash { return sha3.New256() }
+	default:
+		return 0
+	}
+	keyBytes := C.GoBytes(unsafe.Pointer(key), C.int(keyLen))
+	return C.ulonglong(newHandle(hmac.New(newHash, keyBytes)))
+}
+
+//export goated_hmac_equal
+func goated_hmac_equal(mac1 *C.char, mac1Len C.longlong, mac2 *C.char, mac2Len C.longlong) C.bool {
+	b1 := C.GoBytes(unsafe.Pointer(mac1), C.int(mac1Len))
+	b2 := C.GoBytes(unsafe.Pointer(mac2), C.int(mac2Len))
+	return C.bool(hmac.Equal(b1, b2))
+}
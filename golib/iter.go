@@ -0,0 +1,130 @@
+package main
+
+/*
+#include <stdlib.h>
+#include <stdint.h>
+#include <stdbool.h>
+*/
+import "C"
+import "sync"
+
+// iterState pulls a range-over-func iter.Seq[T] one value at a time off a
+// goroutine, so a Python for-loop can consume it without ever materializing
+// the full sequence into a slice handle. The generated goated_<pkg>_<Name>
+// thunk starts the goroutine and hands back a handle; goated_iter_<T>_next
+// and goated_iter_<T>_close below are the fixed, elem-type-keyed thunks
+// every iterator handle is pulled and torn down through.
+type iterState[T any] struct {
+	values chan T
+	done   chan bool
+	stop   chan struct{}
+	once   sync.Once
+}
+
+// newIterState starts seq on its own goroutine. seq's yield func races the
+// value it wants to send against stop, so closing stop unblocks the
+// goroutine whether it's mid-send or hasn't produced its first value yet,
+// and it exits on its own (no send at all) once seq returns naturally.
+func newIterState[T any](seq func(yield func(T) bool)) *iterState[T] {
+	st := &iterState[T]{
+		values: make(chan T),
+		done:   make(chan bool),
+		stop:   make(chan struct{}),
+	}
+	go func() {
+		seq(func(v T) bool {
+			select {
+			case st.values <- v:
+			case <-st.stop:
+				return false
+			}
+			select {
+			case cont := <-st.done:
+				return cont
+			case <-st.stop:
+				return false
+			}
+		})
+		close(st.values)
+	}()
+	return st
+}
+
+// next pulls the next value, telling the producer goroutine it may compute
+// the one after it. ok is false once the sequence is exhausted.
+func (st *iterState[T]) next() (T, bool) {
+	v, ok := <-st.values
+	if ok {
+		st.done <- true
+	}
+	return v, ok
+}
+
+// close unwinds the producer goroutine (see newIterState) exactly once,
+// however far through the sequence it's gotten.
+func (st *iterState[T]) close() {
+	st.once.Do(func() { close(st.stop) })
+}
+
+//export goated_iter_string_next
+func goated_iter_string_next(handle C.ulonglong, okOut *C.bool) *C.char {
+	st, ok := getHandle[*iterState[string]](uint64(handle))
+	if !ok {
+		*okOut = false
+		return nil
+	}
+	v, hasNext := st.next()
+	*okOut = C.bool(hasNext)
+	if !hasNext {
+		return nil
+	}
+	return C.CString(v)
+}
+
+//export goated_iter_string_close
+func goated_iter_string_close(handle C.ulonglong) {
+	if st, ok := getHandle[*iterState[string]](uint64(handle)); ok {
+		st.close()
+	}
+	deleteHandle(uint64(handle))
+}
+
+//export goated_iter_int_next
+func goated_iter_int_next(handle C.ulonglong, okOut *C.bool) C.longlong {
+	st, ok := getHandle[*iterState[int]](uint64(handle))
+	if !ok {
+		*okOut = false
+		return 0
+	}
+	v, hasNext := st.next()
+	*okOut = C.bool(hasNext)
+	return C.longlong(v)
+}
+
+//export goated_iter_int_close
+func goated_iter_int_close(handle C.ulonglong) {
+	if st, ok := getHandle[*iterState[int]](uint64(handle)); ok {
+		st.close()
+	}
+	deleteHandle(uint64(handle))
+}
+
+//export goated_iter_byte_next
+func goated_iter_byte_next(handle C.ulonglong, okOut *C.bool) C.uchar {
+	st, ok := getHandle[*iterState[byte]](uint64(handle))
+	if !ok {
+		*okOut = false
+		return 0
+	}
+	v, hasNext := st.next()
+	*okOut = C.bool(hasNext)
+	return C.uchar(v)
+}
+
+//export goated_iter_byte_close
+func goated_iter_byte_close(handle C.ulonglong) {
+	if st, ok := getHandle[*iterState[byte]](uint64(handle)); ok {
+		st.close()
+	}
+	deleteHandle(uint64(handle))
+}
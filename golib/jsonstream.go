@@ -0,0 +1,209 @@
+package main
+
+/*
+#include <stdlib.h>
+#include <stdint.h>
+#include <stdbool.h>
+
+// goated_json_read_cb/write_cb let a Python-side file-like object back an
+// io.Reader/io.Writer without the whole document passing through a single
+// C.CString, the way goated_json_Marshal/Unmarshal do. Go can't call a C
+// function pointer directly, so the thunks below route the call through
+// these tiny static wrappers.
+typedef long (*goated_json_read_cb)(void* ctx, char* buf, long len);
+typedef long (*goated_json_write_cb)(void* ctx, char* buf, long len);
+
+static inline long goated_json_call_read_cb(goated_json_read_cb cb, void* ctx, char* buf, long len) {
+	return cb(ctx, buf, len);
+}
+
+static inline long goated_json_call_write_cb(goated_json_write_cb cb, void* ctx, char* buf, long len) {
+	return cb(ctx, buf, len);
+}
+*/
+import "C"
+import (
+	"encoding/json"
+	"io"
+	"unsafe"
+)
+
+// cgoReader/cgoWriter adapt a registered C callback + opaque context
+// pointer to Go's io.Reader/io.Writer, so encoding/json's streaming
+// Decoder/Encoder can be driven from Python without materializing the
+// whole document in memory. A negative or zero return from the callback
+// signals EOF/failure, mirroring the read_cb/write_cb typedef contract.
+type cgoReader struct {
+	cb  C.goated_json_read_cb
+	ctx unsafe.Pointer
+}
+
+func (r *cgoReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	n := C.goated_json_call_read_cb(r.cb, r.ctx, (*C.char)(unsafe.Pointer(&p[0])), C.long(len(p)))
+	if n <= 0 {
+		return 0, io.EOF
+	}
+	return int(n), nil
+}
+
+type cgoWriter struct {
+	cb  C.goated_json_write_cb
+	ctx unsafe.Pointer
+}
+
+func (w *cgoWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	n := C.goated_json_call_write_cb(w.cb, w.ctx, (*C.char)(unsafe.Pointer(&p[0])), C.long(len(p)))
+	if int(n) != len(p) {
+		return max(int(n), 0), io.ErrShortWrite
+	}
+	return int(n), nil
+}
+
+// tokenToAny turns a json.Token into a tagged map[string]interface{} so the
+// existing goated_json_GetString/GetNumber/GetBool thunks (which already
+// read a handle via getAny) can pull it apart, instead of adding a new
+// accessor per token kind.
+func tokenToAny(tok json.Token) any {
+	switch t := tok.(type) {
+	case json.Delim:
+		return map[string]any{"kind": "delim", "value": t.String()}
+	case bool:
+		return map[string]any{"kind": "bool", "value": t}
+	case json.Number:
+		return map[string]any{"kind": "number", "value": string(t)}
+	case float64:
+		return map[string]any{"kind": "number", "value": t}
+	case string:
+		return map[string]any{"kind": "string", "value": t}
+	default:
+		return map[string]any{"kind": "null", "value": nil}
+	}
+}
+
+//export goated_json_NewDecoder
+func goated_json_NewDecoder(cb C.goated_json_read_cb, ctx unsafe.Pointer) C.ulonglong {
+	dec := json.NewDecoder(&cgoReader{cb: cb, ctx: ctx})
+	return C.ulonglong(newHandle(dec))
+}
+
+//export goated_json_Decoder_Token
+func goated_json_Decoder_Token(handle C.ulonglong, errOut **C.char) C.ulonglong {
+	dec, ok := getHandle[*json.Decoder](uint64(handle))
+	if !ok {
+		*errOut = C.CString("invalid handle")
+		return 0
+	}
+	tok, err := dec.Token()
+	if err != nil {
+		if err == io.EOF {
+			*errOut = nil
+			return 0
+		}
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newHandle(tokenToAny(tok)))
+}
+
+//export goated_json_Decoder_Decode
+func goated_json_Decoder_Decode(handle C.ulonglong, errOut **C.char) C.ulonglong {
+	dec, ok := getHandle[*json.Decoder](uint64(handle))
+	if !ok {
+		*errOut = C.CString("invalid handle")
+		return 0
+	}
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newHandle(v))
+}
+
+//export goated_json_Decoder_More
+func goated_json_Decoder_More(handle C.ulonglong) C.bool {
+	dec, ok := getHandle[*json.Decoder](uint64(handle))
+	if !ok {
+		return false
+	}
+	return C.bool(dec.More())
+}
+
+//export goated_json_Decoder_InputOffset
+func goated_json_Decoder_InputOffset(handle C.ulonglong) C.longlong {
+	dec, ok := getHandle[*json.Decoder](uint64(handle))
+	if !ok {
+		return 0
+	}
+	return C.longlong(dec.InputOffset())
+}
+
+//export goated_json_UseNumber
+func goated_json_UseNumber(handle C.ulonglong) {
+	dec, ok := getHandle[*json.Decoder](uint64(handle))
+	if !ok {
+		return
+	}
+	dec.UseNumber()
+}
+
+//export goated_json_Decoder_Free
+func goated_json_Decoder_Free(handle C.ulonglong) {
+	deleteHandle(uint64(handle))
+}
+
+//export goated_json_NewEncoder
+func goated_json_NewEncoder(cb C.goated_json_write_cb, ctx unsafe.Pointer) C.ulonglong {
+	enc := json.NewEncoder(&cgoWriter{cb: cb, ctx: ctx})
+	return C.ulonglong(newHandle(enc))
+}
+
+//export goated_json_Encoder_Encode
+func goated_json_Encoder_Encode(handle C.ulonglong, objHandle C.ulonglong, errOut **C.char) {
+	enc, ok := getHandle[*json.Encoder](uint64(handle))
+	if !ok {
+		*errOut = C.CString("invalid handle")
+		return
+	}
+	obj, ok := getAny(uint64(objHandle))
+	if !ok {
+		*errOut = C.CString("invalid object handle")
+		return
+	}
+	if err := enc.Encode(obj); err != nil {
+		*errOut = C.CString(err.Error())
+		return
+	}
+	*errOut = nil
+}
+
+//export goated_json_Encoder_SetIndent
+func goated_json_Encoder_SetIndent(handle C.ulonglong, prefix *C.char, indent *C.char) {
+	enc, ok := getHandle[*json.Encoder](uint64(handle))
+	if !ok {
+		return
+	}
+	enc.SetIndent(C.GoString(prefix), C.GoString(indent))
+}
+
+//export goated_json_Encoder_SetEscapeHTML
+func goated_json_Encoder_SetEscapeHTML(handle C.ulonglong, on C.bool) {
+	enc, ok := getHandle[*json.Encoder](uint64(handle))
+	if !ok {
+		return
+	}
+	enc.SetEscapeHTML(bool(on))
+}
+
+//export goated_json_Encoder_Free
+func goated_json_Encoder_Free(handle C.ulonglong) {
+	deleteHandle(uint64(handle))
+}
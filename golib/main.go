@@ -0,0 +1,7 @@
+package main
+
+// main is never called: this package is built with -buildmode=c-shared,
+// where every //export thunk in this package is reachable from C/Python without ever
+// invoking main. The func is only here because `go build` requires a main
+// package to declare one, c-shared or not.
+func main() {}
@@ -71,6 +71,35 @@ func goated_math_Ceil(x C.double) C.double {
 	return C.double(result)
 }
 
+// mathClass* are the fpclassify-style buckets returned by goated_math_Classify.
+const (
+	mathClassNormal = iota
+	mathClassZero
+	mathClassSubnormal
+	mathClassInf
+	mathClassNegInf
+	mathClassNaN
+)
+
+//export goated_math_Classify
+func goated_math_Classify(x C.double) C.int {
+	f := float64(x)
+	switch {
+	case math.IsNaN(f):
+		return C.int(mathClassNaN)
+	case math.IsInf(f, 1):
+		return C.int(mathClassInf)
+	case math.IsInf(f, -1):
+		return C.int(mathClassNegInf)
+	case f == 0:
+		return C.int(mathClassZero)
+	}
+	if (math.Float64bits(f)>>52)&0x7FF == 0 {
+		return C.int(mathClassSubnormal)
+	}
+	return C.int(mathClassNormal)
+}
+
 //export goated_math_Copysign
 func goated_math_Copysign(f C.double, sign C.double) C.double {
 	result := math.Copysign(float64(f), float64(sign))
@@ -173,6 +202,17 @@ func goated_math_Floor(x C.double) C.double {
 	return C.double(result)
 }
 
+// goated_math_Frexp breaks x into a normalized fraction and a power of two,
+// the way C99's frexp does, handing the exponent back through expOut since
+// cgo exports can only return one value directly.
+//
+//export goated_math_Frexp
+func goated_math_Frexp(x C.double, expOut *C.longlong) C.double {
+	frac, exp := math.Frexp(float64(x))
+	*expOut = C.longlong(exp)
+	return C.double(frac)
+}
+
 //export goated_math_Gamma
 func goated_math_Gamma(x C.double) C.double {
 	result := math.Gamma(float64(x))
@@ -233,6 +273,17 @@ func goated_math_Ldexp(frac C.double, exp C.longlong) C.double {
 	return C.double(result)
 }
 
+// goated_math_Lgamma returns the natural log of the absolute value of
+// Gamma(x) along with its sign (+/-1), the sign coming back through signOut
+// the same out-pointer way goated_math_Frexp hands back its exponent.
+//
+//export goated_math_Lgamma
+func goated_math_Lgamma(x C.double, signOut *C.longlong) C.double {
+	lgamma, sign := math.Lgamma(float64(x))
+	*signOut = C.longlong(sign)
+	return C.double(lgamma)
+}
+
 //export goated_math_Log
 func goated_math_Log(x C.double) C.double {
 	result := math.Log(float64(x))
@@ -281,6 +332,16 @@ func goated_math_Mod(x C.double, y C.double) C.double {
 	return C.double(result)
 }
 
+// goated_math_Modf splits x into integer and fractional parts, both with
+// the sign of x, handing the integer part back through intOut.
+//
+//export goated_math_Modf
+func goated_math_Modf(x C.double, intOut *C.double) C.double {
+	intPart, frac := math.Modf(float64(x))
+	*intOut = C.double(intPart)
+	return C.double(frac)
+}
+
 //export goated_math_NaN
 func goated_math_NaN() C.double {
 	result := math.NaN()
@@ -341,6 +402,17 @@ func goated_math_Sin(x C.double) C.double {
 	return C.double(result)
 }
 
+// goated_math_Sincos returns sin(x) directly and hands cos(x) back through
+// cosOut, avoiding two separate trips through cgo for callers that need
+// both (e.g. rotation matrices).
+//
+//export goated_math_Sincos
+func goated_math_Sincos(x C.double, cosOut *C.double) C.double {
+	sin, cos := math.Sincos(float64(x))
+	*cosOut = C.double(cos)
+	return C.double(sin)
+}
+
 //export goated_math_Sinh
 func goated_math_Sinh(x C.double) C.double {
 	result := math.Sinh(float64(x))
@@ -0,0 +1,270 @@
+package main
+
+/*
+#include <stdlib.h>
+#include <stdint.h>
+#include <stdbool.h>
+*/
+import "C"
+import (
+	"math"
+)
+
+// goated_mathf_* mirrors the goated_math_* surface but takes and returns
+// float32 (C.float), so callers on embedded/GPU-adjacent targets can stay in
+// single precision on their side of the boundary. The computation itself is
+// not done in float32: Go's math package only operates on float64, so each wrapper casts its
+// argument up, calls the float64 implementation, and casts the result back
+// down to float32.
+
+//export goated_mathf_Abs
+func goated_mathf_Abs(x C.float) C.float {
+	result := math.Abs(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Acos
+func goated_mathf_Acos(x C.float) C.float {
+	result := math.Acos(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Acosh
+func goated_mathf_Acosh(x C.float) C.float {
+	result := math.Acosh(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Asin
+func goated_mathf_Asin(x C.float) C.float {
+	result := math.Asin(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Asinh
+func goated_mathf_Asinh(x C.float) C.float {
+	result := math.Asinh(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Atan
+func goated_mathf_Atan(x C.float) C.float {
+	result := math.Atan(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Atan2
+func goated_mathf_Atan2(y C.float, x C.float) C.float {
+	result := math.Atan2(float64(y), float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Atanh
+func goated_mathf_Atanh(x C.float) C.float {
+	result := math.Atanh(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Cbrt
+func goated_mathf_Cbrt(x C.float) C.float {
+	result := math.Cbrt(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Ceil
+func goated_mathf_Ceil(x C.float) C.float {
+	result := math.Ceil(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Copysign
+func goated_mathf_Copysign(f C.float, sign C.float) C.float {
+	result := math.Copysign(float64(f), float64(sign))
+	return C.float(result)
+}
+
+//export goated_mathf_Cos
+func goated_mathf_Cos(x C.float) C.float {
+	result := math.Cos(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Cosh
+func goated_mathf_Cosh(x C.float) C.float {
+	result := math.Cosh(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Dim
+func goated_mathf_Dim(x C.float, y C.float) C.float {
+	result := math.Dim(float64(x), float64(y))
+	return C.float(result)
+}
+
+//export goated_mathf_Exp
+func goated_mathf_Exp(x C.float) C.float {
+	result := math.Exp(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Exp2
+func goated_mathf_Exp2(x C.float) C.float {
+	result := math.Exp2(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Expm1
+func goated_mathf_Expm1(x C.float) C.float {
+	result := math.Expm1(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Floor
+func goated_mathf_Floor(x C.float) C.float {
+	result := math.Floor(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Gamma
+func goated_mathf_Gamma(x C.float) C.float {
+	result := math.Gamma(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Hypot
+func goated_mathf_Hypot(p C.float, q C.float) C.float {
+	result := math.Hypot(float64(p), float64(q))
+	return C.float(result)
+}
+
+//export goated_mathf_Log
+func goated_mathf_Log(x C.float) C.float {
+	result := math.Log(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Log10
+func goated_mathf_Log10(x C.float) C.float {
+	result := math.Log10(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Log1p
+func goated_mathf_Log1p(x C.float) C.float {
+	result := math.Log1p(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Log2
+func goated_mathf_Log2(x C.float) C.float {
+	result := math.Log2(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Max
+func goated_mathf_Max(x C.float, y C.float) C.float {
+	result := math.Max(float64(x), float64(y))
+	return C.float(result)
+}
+
+//export goated_mathf_Min
+func goated_mathf_Min(x C.float, y C.float) C.float {
+	result := math.Min(float64(x), float64(y))
+	return C.float(result)
+}
+
+//export goated_mathf_Mod
+func goated_mathf_Mod(x C.float, y C.float) C.float {
+	result := math.Mod(float64(x), float64(y))
+	return C.float(result)
+}
+
+//export goated_mathf_Pow
+func goated_mathf_Pow(x C.float, y C.float) C.float {
+	result := math.Pow(float64(x), float64(y))
+	return C.float(result)
+}
+
+//export goated_mathf_Remainder
+func goated_mathf_Remainder(x C.float, y C.float) C.float {
+	result := math.Remainder(float64(x), float64(y))
+	return C.float(result)
+}
+
+//export goated_mathf_Round
+func goated_mathf_Round(x C.float) C.float {
+	result := math.Round(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_RoundToEven
+func goated_mathf_RoundToEven(x C.float) C.float {
+	result := math.RoundToEven(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Signbit
+func goated_mathf_Signbit(x C.float) C.bool {
+	result := math.Signbit(float64(x))
+	return C.bool(result)
+}
+
+//export goated_mathf_Sin
+func goated_mathf_Sin(x C.float) C.float {
+	result := math.Sin(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Sinh
+func goated_mathf_Sinh(x C.float) C.float {
+	result := math.Sinh(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Sqrt
+func goated_mathf_Sqrt(x C.float) C.float {
+	result := math.Sqrt(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Tan
+func goated_mathf_Tan(x C.float) C.float {
+	result := math.Tan(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Tanh
+func goated_mathf_Tanh(x C.float) C.float {
+	result := math.Tanh(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_Trunc
+func goated_mathf_Trunc(x C.float) C.float {
+	result := math.Trunc(float64(x))
+	return C.float(result)
+}
+
+//export goated_mathf_IsNaN
+func goated_mathf_IsNaN(f C.float) C.bool {
+	result := math.IsNaN(float64(f))
+	return C.bool(result)
+}
+
+//export goated_mathf_IsInf
+func goated_mathf_IsInf(f C.float, sign C.longlong) C.bool {
+	result := math.IsInf(float64(f), int(sign))
+	return C.bool(result)
+}
+
+//export goated_mathf_Inf
+func goated_mathf_Inf(sign C.longlong) C.float {
+	result := math.Inf(int(sign))
+	return C.float(result)
+}
+
+//export goated_mathf_NaN
+func goated_mathf_NaN() C.float {
+	result := math.NaN()
+	return C.float(result)
+}
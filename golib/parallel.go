@@ -12,6 +12,8 @@ import (
 	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/hex"
+	"math/big"
+	"regexp"
 	"runtime"
 	"strings"
 	"sync"
@@ -252,6 +254,103 @@ func goated_parallel_hash_sha1_batch(
 	wg.Wait()
 }
 
+// Batch regexp matching - reuse one compiled pattern across many inputs
+// instead of paying regexp.Compile's cost on every call. Each input can
+// have any number of matches, so results comes back as one string-slice
+// handle per text (readable via the existing goated_slice_string_*
+// thunks) rather than a single flattened match per text.
+
+//export goated_parallel_regexp_findall_batch
+func goated_parallel_regexp_findall_batch(
+	handle C.ulonglong,
+	texts **C.char, textCount C.int,
+	results *C.ulonglong,
+) {
+	re, ok := getHandle[*regexp.Regexp](uint64(handle))
+	if !ok {
+		return
+	}
+	count := int(textCount)
+
+	var wg sync.WaitGroup
+	resultSlice := (*[1 << 30]C.ulonglong)(unsafe.Pointer(results))[:count:count]
+	textSlice := (*[1 << 30]*C.char)(unsafe.Pointer(texts))[:count:count]
+
+	numWorkers := runtime.NumCPU()
+	chunkSize := (count + numWorkers - 1) / numWorkers
+
+	for i := 0; i < numWorkers; i++ {
+		start := i * chunkSize
+		end := start + chunkSize
+		if end > count {
+			end = count
+		}
+		if start >= count {
+			break
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for j := start; j < end; j++ {
+				text := C.GoString(textSlice[j])
+				matches := re.FindAllString(text, -1)
+				resultSlice[j] = C.ulonglong(newHandle(matches))
+			}
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+// Batch modular exponentiation - decimal-string bases/exps/mods in, decimal
+// string results out, fanned out across workers the same way the hash
+// batch exports are above. Useful for RSA/DH-style workloads where the
+// same modulus is reused across many (base, exp) pairs.
+
+//export goated_parallel_big_expmod_batch
+func goated_parallel_big_expmod_batch(
+	bases **C.char, exps **C.char, mods **C.char, count C.int,
+	results **C.char,
+) {
+	n := int(count)
+
+	var wg sync.WaitGroup
+	baseSlice := (*[1 << 30]*C.char)(unsafe.Pointer(bases))[:n:n]
+	expSlice := (*[1 << 30]*C.char)(unsafe.Pointer(exps))[:n:n]
+	modSlice := (*[1 << 30]*C.char)(unsafe.Pointer(mods))[:n:n]
+	resultSlice := (*[1 << 30]*C.char)(unsafe.Pointer(results))[:n:n]
+
+	numWorkers := runtime.NumCPU()
+	chunkSize := (n + numWorkers - 1) / numWorkers
+
+	for i := 0; i < numWorkers; i++ {
+		start := i * chunkSize
+		end := start + chunkSize
+		if end > n {
+			end = n
+		}
+		if start >= n {
+			break
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for j := start; j < end; j++ {
+				base, ok1 := new(big.Int).SetString(C.GoString(baseSlice[j]), 10)
+				exp, ok2 := new(big.Int).SetString(C.GoString(expSlice[j]), 10)
+				mod, ok3 := new(big.Int).SetString(C.GoString(modSlice[j]), 10)
+				if !ok1 || !ok2 || !ok3 {
+					resultSlice[j] = C.CString("")
+					continue
+				}
+				resultSlice[j] = C.CString(new(big.Int).Exp(base, exp, mod).String())
+			}
+		}(start, end)
+	}
+	wg.Wait()
+}
+
 // Parallel map operation - apply a transform to each item
 
 //export goated_parallel_map_toupper
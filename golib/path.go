@@ -17,24 +17,44 @@ func goated_path_Base(path_ *C.char) *C.char {
 	return C.CString(result)
 }
 
+//export goated_path_Base_Into
+func goated_path_Base_Into(path_ *C.char, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, path.Base(C.GoString(path_)))
+}
+
 //export goated_path_Clean
 func goated_path_Clean(path_ *C.char) *C.char {
 	result := path.Clean(C.GoString(path_))
 	return C.CString(result)
 }
 
+//export goated_path_Clean_Into
+func goated_path_Clean_Into(path_ *C.char, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, path.Clean(C.GoString(path_)))
+}
+
 //export goated_path_Dir
 func goated_path_Dir(path_ *C.char) *C.char {
 	result := path.Dir(C.GoString(path_))
 	return C.CString(result)
 }
 
+//export goated_path_Dir_Into
+func goated_path_Dir_Into(path_ *C.char, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, path.Dir(C.GoString(path_)))
+}
+
 //export goated_path_Ext
 func goated_path_Ext(path_ *C.char) *C.char {
 	result := path.Ext(C.GoString(path_))
 	return C.CString(result)
 }
 
+//export goated_path_Ext_Into
+func goated_path_Ext_Into(path_ *C.char, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, path.Ext(C.GoString(path_)))
+}
+
 //export goated_path_IsAbs
 func goated_path_IsAbs(path_ *C.char) C.bool {
 	result := path.IsAbs(C.GoString(path_))
@@ -4,13 +4,23 @@ package main
 #include <stdlib.h>
 #include <stdint.h>
 #include <stdbool.h>
+
+// goated_regexp_replace_cb mirrors ReplaceAllStringFunc's replacer: it is
+// handed a matched substring and returns the text to replace it with.
+// Go can't call a C function pointer directly, so the thunk below routes
+// the call through this tiny static wrapper.
+typedef char* (*goated_regexp_replace_cb)(char*);
+
+static inline char* goated_regexp_call_replace_cb(goated_regexp_replace_cb cb, char* match) {
+	return cb(match);
+}
 */
 import "C"
 import (
 	"regexp"
+	"unsafe"
 )
 
-
 //export goated_regexp_MatchString
 func goated_regexp_MatchString(pattern *C.char, s *C.char, errOut **C.char) C.bool {
 	result, err := regexp.MatchString(C.GoString(pattern), C.GoString(s))
@@ -28,3 +38,105 @@ func goated_regexp_QuoteMeta(s *C.char) *C.char {
 	return C.CString(result)
 }
 
+// goated_regexp_Compile and the handle-taking thunks below let Python
+// compile a pattern once and reuse it, instead of re-compiling on every
+// call the way goated_regexp_MatchString does.
+
+//export goated_regexp_Compile
+func goated_regexp_Compile(pattern *C.char, errOut **C.char) C.ulonglong {
+	re, err := regexp.Compile(C.GoString(pattern))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newHandle(re))
+}
+
+//export goated_regexp_FindString
+func goated_regexp_FindString(handle C.ulonglong, s *C.char) *C.char {
+	re, ok := getHandle[*regexp.Regexp](uint64(handle))
+	if !ok {
+		return nil
+	}
+	return C.CString(re.FindString(C.GoString(s)))
+}
+
+//export goated_regexp_FindAllString
+func goated_regexp_FindAllString(handle C.ulonglong, s *C.char, n C.longlong) C.ulonglong {
+	re, ok := getHandle[*regexp.Regexp](uint64(handle))
+	if !ok {
+		return 0
+	}
+	result := re.FindAllString(C.GoString(s), int(n))
+	return C.ulonglong(newHandle(result))
+}
+
+//export goated_regexp_FindStringSubmatch
+func goated_regexp_FindStringSubmatch(handle C.ulonglong, s *C.char) C.ulonglong {
+	re, ok := getHandle[*regexp.Regexp](uint64(handle))
+	if !ok {
+		return 0
+	}
+	result := re.FindStringSubmatch(C.GoString(s))
+	return C.ulonglong(newHandle(result))
+}
+
+//export goated_regexp_ReplaceAllString
+func goated_regexp_ReplaceAllString(handle C.ulonglong, src *C.char, repl *C.char) *C.char {
+	re, ok := getHandle[*regexp.Regexp](uint64(handle))
+	if !ok {
+		return nil
+	}
+	return C.CString(re.ReplaceAllString(C.GoString(src), C.GoString(repl)))
+}
+
+//export goated_regexp_ReplaceAllStringFunc
+func goated_regexp_ReplaceAllStringFunc(handle C.ulonglong, src *C.char, cb C.goated_regexp_replace_cb) *C.char {
+	re, ok := getHandle[*regexp.Regexp](uint64(handle))
+	if !ok {
+		return nil
+	}
+	result := re.ReplaceAllStringFunc(C.GoString(src), func(match string) string {
+		cMatch := C.CString(match)
+		defer C.free(unsafe.Pointer(cMatch))
+		cResult := C.goated_regexp_call_replace_cb(cb, cMatch)
+		defer C.free(unsafe.Pointer(cResult))
+		return C.GoString(cResult)
+	})
+	return C.CString(result)
+}
+
+//export goated_regexp_Split
+func goated_regexp_Split(handle C.ulonglong, s *C.char, n C.longlong) C.ulonglong {
+	re, ok := getHandle[*regexp.Regexp](uint64(handle))
+	if !ok {
+		return 0
+	}
+	result := re.Split(C.GoString(s), int(n))
+	return C.ulonglong(newHandle(result))
+}
+
+//export goated_regexp_NumSubexp
+func goated_regexp_NumSubexp(handle C.ulonglong) C.longlong {
+	re, ok := getHandle[*regexp.Regexp](uint64(handle))
+	if !ok {
+		return 0
+	}
+	return C.longlong(re.NumSubexp())
+}
+
+//export goated_regexp_SubexpNames
+func goated_regexp_SubexpNames(handle C.ulonglong) C.ulonglong {
+	re, ok := getHandle[*regexp.Regexp](uint64(handle))
+	if !ok {
+		return 0
+	}
+	return C.ulonglong(newHandle(re.SubexpNames()))
+}
+
+//export goated_regexp_Free
+func goated_regexp_Free(handle C.ulonglong) {
+	deleteHandle(uint64(handle))
+}
+
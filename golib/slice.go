@@ -0,0 +1,63 @@
+package main
+
+/*
+#include <stdlib.h>
+#include <stdint.h>
+#include <stdbool.h>
+*/
+import "C"
+import "unsafe"
+
+// These thunks let Python build a []string/[]int/[]byte on the Go side one
+// element at a time (or, for bytes, in one memcpy) and hand back a handle
+// that a slice-typed parameter can then consume, mirroring the handles
+// already produced by slice-returning functions like strings.Split.
+
+//export goated_slice_string_new
+func goated_slice_string_new() C.ulonglong {
+	return C.ulonglong(newHandle([]string{}))
+}
+
+//export goated_slice_string_append
+func goated_slice_string_append(handle C.ulonglong, s *C.char) {
+	slice, ok := getStringSlice(uint64(handle))
+	if !ok {
+		return
+	}
+	handles.Store(uint64(handle), append(slice, C.GoString(s)))
+}
+
+//export goated_slice_string_free
+func goated_slice_string_free(handle C.ulonglong) {
+	deleteHandle(uint64(handle))
+}
+
+//export goated_slice_int_new
+func goated_slice_int_new() C.ulonglong {
+	return C.ulonglong(newHandle([]int{}))
+}
+
+//export goated_slice_int_append
+func goated_slice_int_append(handle C.ulonglong, n C.longlong) {
+	slice, ok := getIntSlice(uint64(handle))
+	if !ok {
+		return
+	}
+	handles.Store(uint64(handle), append(slice, int(n)))
+}
+
+//export goated_slice_int_free
+func goated_slice_int_free(handle C.ulonglong) {
+	deleteHandle(uint64(handle))
+}
+
+//export goated_slice_byte_from_bytes
+func goated_slice_byte_from_bytes(data *C.char, dataLen C.longlong) C.ulonglong {
+	b := C.GoBytes(unsafe.Pointer(data), C.int(dataLen))
+	return C.ulonglong(newHandle(b))
+}
+
+//export goated_slice_byte_free
+func goated_slice_byte_free(handle C.ulonglong) {
+	deleteHandle(uint64(handle))
+}
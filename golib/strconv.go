@@ -7,7 +7,9 @@ package main
 */
 import "C"
 import (
+	"errors"
 	"strconv"
+	"unsafe"
 )
 
 // ParseInt result structure
@@ -38,6 +40,11 @@ func goated_strconv_Itoa(i C.longlong) *C.char {
 	return C.CString(strconv.Itoa(int(i)))
 }
 
+//export goated_strconv_Itoa_Into
+func goated_strconv_Itoa_Into(i C.longlong, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, strconv.Itoa(int(i)))
+}
+
 //export goated_strconv_ParseInt
 func goated_strconv_ParseInt(s *C.char, base C.int, bitSize C.int, errOut **C.char) C.longlong {
 	result, err := strconv.ParseInt(C.GoString(s), int(base), int(bitSize))
@@ -60,6 +67,85 @@ func goated_strconv_ParseUint(s *C.char, base C.int, bitSize C.int, errOut **C.c
 	return C.ulonglong(result)
 }
 
+// setRangeAwareErr mirrors Go's own bitSize semantics for the width-specific
+// ParseInt*/ParseUint* wrappers below: strconv already wraps out-of-range
+// values in a *NumError around ErrRange, so this just surfaces that case as
+// a distinct "ErrRange" string instead of the longer NumError message, so C
+// callers can branch on overflow without a substring match.
+func setRangeAwareErr(errOut **C.char, err error) {
+	if errors.Is(err, strconv.ErrRange) {
+		*errOut = C.CString("ErrRange")
+		return
+	}
+	*errOut = C.CString(err.Error())
+}
+
+//export goated_strconv_ParseInt8
+func goated_strconv_ParseInt8(s *C.char, base C.int, errOut **C.char) C.schar {
+	result, err := strconv.ParseInt(C.GoString(s), int(base), 8)
+	if err != nil {
+		setRangeAwareErr(errOut, err)
+		return 0
+	}
+	*errOut = nil
+	return C.schar(result)
+}
+
+//export goated_strconv_ParseInt16
+func goated_strconv_ParseInt16(s *C.char, base C.int, errOut **C.char) C.short {
+	result, err := strconv.ParseInt(C.GoString(s), int(base), 16)
+	if err != nil {
+		setRangeAwareErr(errOut, err)
+		return 0
+	}
+	*errOut = nil
+	return C.short(result)
+}
+
+//export goated_strconv_ParseInt32
+func goated_strconv_ParseInt32(s *C.char, base C.int, errOut **C.char) C.int {
+	result, err := strconv.ParseInt(C.GoString(s), int(base), 32)
+	if err != nil {
+		setRangeAwareErr(errOut, err)
+		return 0
+	}
+	*errOut = nil
+	return C.int(result)
+}
+
+//export goated_strconv_ParseUint8
+func goated_strconv_ParseUint8(s *C.char, base C.int, errOut **C.char) C.uchar {
+	result, err := strconv.ParseUint(C.GoString(s), int(base), 8)
+	if err != nil {
+		setRangeAwareErr(errOut, err)
+		return 0
+	}
+	*errOut = nil
+	return C.uchar(result)
+}
+
+//export goated_strconv_ParseUint16
+func goated_strconv_ParseUint16(s *C.char, base C.int, errOut **C.char) C.ushort {
+	result, err := strconv.ParseUint(C.GoString(s), int(base), 16)
+	if err != nil {
+		setRangeAwareErr(errOut, err)
+		return 0
+	}
+	*errOut = nil
+	return C.ushort(result)
+}
+
+//export goated_strconv_ParseUint32
+func goated_strconv_ParseUint32(s *C.char, base C.int, errOut **C.char) C.uint {
+	result, err := strconv.ParseUint(C.GoString(s), int(base), 32)
+	if err != nil {
+		setRangeAwareErr(errOut, err)
+		return 0
+	}
+	*errOut = nil
+	return C.uint(result)
+}
+
 //export goated_strconv_ParseFloat
 func goated_strconv_ParseFloat(s *C.char, bitSize C.int, errOut **C.char) C.double {
 	result, err := strconv.ParseFloat(C.GoString(s), int(bitSize))
@@ -87,31 +173,129 @@ func goated_strconv_FormatInt(i C.longlong, base C.int) *C.char {
 	return C.CString(strconv.FormatInt(int64(i), int(base)))
 }
 
+//export goated_strconv_FormatInt_Into
+func goated_strconv_FormatInt_Into(i C.longlong, base C.int, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, strconv.FormatInt(int64(i), int(base)))
+}
+
 //export goated_strconv_FormatUint
 func goated_strconv_FormatUint(i C.ulonglong, base C.int) *C.char {
 	return C.CString(strconv.FormatUint(uint64(i), int(base)))
 }
 
+//export goated_strconv_FormatUint_Into
+func goated_strconv_FormatUint_Into(i C.ulonglong, base C.int, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, strconv.FormatUint(uint64(i), int(base)))
+}
+
 //export goated_strconv_FormatFloat
 func goated_strconv_FormatFloat(f C.double, fmt C.char, prec C.int, bitSize C.int) *C.char {
 	return C.CString(strconv.FormatFloat(float64(f), byte(fmt), int(prec), int(bitSize)))
 }
 
+//export goated_strconv_FormatFloat_Into
+func goated_strconv_FormatFloat_Into(f C.double, fmt C.char, prec C.int, bitSize C.int, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, strconv.FormatFloat(float64(f), byte(fmt), int(prec), int(bitSize)))
+}
+
+//export goated_strconv_FormatInt32
+func goated_strconv_FormatInt32(i C.int, base C.int) *C.char {
+	return C.CString(strconv.FormatInt(int64(i), int(base)))
+}
+
+//export goated_strconv_FormatInt32_Into
+func goated_strconv_FormatInt32_Into(i C.int, base C.int, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, strconv.FormatInt(int64(i), int(base)))
+}
+
+//export goated_strconv_FormatUint32
+func goated_strconv_FormatUint32(i C.uint, base C.int) *C.char {
+	return C.CString(strconv.FormatUint(uint64(i), int(base)))
+}
+
+//export goated_strconv_FormatUint32_Into
+func goated_strconv_FormatUint32_Into(i C.uint, base C.int, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, strconv.FormatUint(uint64(i), int(base)))
+}
+
+//export goated_strconv_FormatFloat32
+func goated_strconv_FormatFloat32(f C.float, fmt C.char, prec C.int) *C.char {
+	return C.CString(strconv.FormatFloat(float64(f), byte(fmt), int(prec), 32))
+}
+
+//export goated_strconv_FormatFloat32_Into
+func goated_strconv_FormatFloat32_Into(f C.float, fmt C.char, prec C.int, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, strconv.FormatFloat(float64(f), byte(fmt), int(prec), 32))
+}
+
 //export goated_strconv_FormatBool
 func goated_strconv_FormatBool(b C.bool) *C.char {
 	return C.CString(strconv.FormatBool(bool(b)))
 }
 
+//export goated_strconv_FormatBool_Into
+func goated_strconv_FormatBool_Into(b C.bool, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, strconv.FormatBool(bool(b)))
+}
+
+// copyIntoBuf writes s into the caller-provided buf, NUL-terminating it the
+// way snprintf does: it always returns len(s) (the length that would have
+// been written), but only ever writes bufLen-1 payload bytes plus a
+// trailing NUL, so callers that compare the return value against bufLen
+// and then treat buf as a C string never read or write past what they
+// allocated. This lets high-throughput callers reuse one buffer across
+// many calls instead of paying for a C.CString allocation (and matching
+// C.free) every time, which the plain Format*/Append-less exports above
+// cannot avoid.
+func copyIntoBuf(buf *C.char, bufLen C.int, s string) C.int {
+	n := len(s)
+	if bufLen > 0 {
+		out := unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(bufLen))
+		copyLen := n
+		if copyLen > int(bufLen)-1 {
+			copyLen = int(bufLen) - 1
+		}
+		copy(out, s[:copyLen])
+		out[copyLen] = 0
+	}
+	return C.int(n)
+}
+
+//export goated_strconv_AppendInt
+func goated_strconv_AppendInt(buf *C.char, bufLen C.int, i C.longlong, base C.int) C.int {
+	return copyIntoBuf(buf, bufLen, strconv.FormatInt(int64(i), int(base)))
+}
+
+//export goated_strconv_AppendFloat
+func goated_strconv_AppendFloat(buf *C.char, bufLen C.int, f C.double, fmt C.char, prec C.int, bitSize C.int) C.int {
+	return copyIntoBuf(buf, bufLen, strconv.FormatFloat(float64(f), byte(fmt), int(prec), int(bitSize)))
+}
+
+//export goated_strconv_AppendQuote
+func goated_strconv_AppendQuote(buf *C.char, bufLen C.int, s *C.char) C.int {
+	return copyIntoBuf(buf, bufLen, strconv.Quote(C.GoString(s)))
+}
+
 //export goated_strconv_Quote
 func goated_strconv_Quote(s *C.char) *C.char {
 	return C.CString(strconv.Quote(C.GoString(s)))
 }
 
+//export goated_strconv_Quote_Into
+func goated_strconv_Quote_Into(s *C.char, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, strconv.Quote(C.GoString(s)))
+}
+
 //export goated_strconv_QuoteToASCII
 func goated_strconv_QuoteToASCII(s *C.char) *C.char {
 	return C.CString(strconv.QuoteToASCII(C.GoString(s)))
 }
 
+//export goated_strconv_QuoteToASCII_Into
+func goated_strconv_QuoteToASCII_Into(s *C.char, buf *C.char, bufLen C.int) C.int {
+	return copyIntoBuf(buf, bufLen, strconv.QuoteToASCII(C.GoString(s)))
+}
+
 //export goated_strconv_Unquote
 func goated_strconv_Unquote(s *C.char, errOut **C.char) *C.char {
 	result, err := strconv.Unquote(C.GoString(s))
@@ -122,3 +306,14 @@ func goated_strconv_Unquote(s *C.char, errOut **C.char) *C.char {
 	*errOut = nil
 	return C.CString(result)
 }
+
+//export goated_strconv_Unquote_Into
+func goated_strconv_Unquote_Into(s *C.char, buf *C.char, bufLen C.int, errOut **C.char) C.int {
+	result, err := strconv.Unquote(C.GoString(s))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return copyIntoBuf(buf, bufLen, result)
+}
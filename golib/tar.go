@@ -0,0 +1,148 @@
+package main
+
+/*
+#include <stdlib.h>
+#include <stdint.h>
+#include <stdbool.h>
+*/
+import "C"
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"time"
+	"unsafe"
+)
+
+// tarReader and tarWriter bundle the archive/tar type with the underlying
+// file so goated_tar_Free can close the file (a *tar.Reader has no Close of
+// its own) and goated_tar_WriterClose can close the *tar.Writer and then
+// the file, in that order.
+type tarReader struct {
+	f  *os.File
+	tr *tar.Reader
+}
+
+type tarWriter struct {
+	f  *os.File
+	tw *tar.Writer
+}
+
+//export goated_tar_OpenReader
+func goated_tar_OpenReader(path *C.char, errOut **C.char) C.ulonglong {
+	f, err := os.Open(C.GoString(path))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newHandle(&tarReader{f: f, tr: tar.NewReader(f)}))
+}
+
+// goated_tar_Next advances to the next entry and hands back its header as a
+// map[string]interface{} handle, so the existing goated_json_GetString/
+// GetNumber thunks (which already read a handle via getAny) can pull out
+// individual fields instead of a new accessor per field. Returns 0 with
+// errOut nil at end of archive.
+//
+//export goated_tar_Next
+func goated_tar_Next(handle C.ulonglong, errOut **C.char) C.ulonglong {
+	r, ok := getHandle[*tarReader](uint64(handle))
+	if !ok {
+		*errOut = C.CString("invalid handle")
+		return 0
+	}
+	hdr, err := r.tr.Next()
+	if err == io.EOF {
+		*errOut = nil
+		return 0
+	}
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newHandle(map[string]interface{}{
+		"name":     hdr.Name,
+		"size":     float64(hdr.Size),
+		"mode":     float64(hdr.Mode),
+		"modtime":  float64(hdr.ModTime.Unix()),
+		"typeflag": string(hdr.Typeflag),
+	}))
+}
+
+//export goated_tar_Read
+func goated_tar_Read(handle C.ulonglong, buf *C.char, bufLen C.longlong) C.longlong {
+	r, ok := getHandle[*tarReader](uint64(handle))
+	if !ok {
+		return -1
+	}
+	n, err := r.tr.Read(unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(bufLen)))
+	if n == 0 && err != nil {
+		return -1
+	}
+	return C.longlong(n)
+}
+
+//export goated_tar_Free
+func goated_tar_Free(handle C.ulonglong) {
+	if r, ok := getHandle[*tarReader](uint64(handle)); ok {
+		r.f.Close()
+	}
+	deleteHandle(uint64(handle))
+}
+
+//export goated_tar_NewWriter
+func goated_tar_NewWriter(path *C.char, errOut **C.char) C.ulonglong {
+	f, err := os.Create(C.GoString(path))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newHandle(&tarWriter{f: f, tw: tar.NewWriter(f)}))
+}
+
+//export goated_tar_WriteHeader
+func goated_tar_WriteHeader(handle C.ulonglong, name *C.char, size C.longlong, mode C.longlong, errOut **C.char) {
+	w, ok := getHandle[*tarWriter](uint64(handle))
+	if !ok {
+		*errOut = C.CString("invalid handle")
+		return
+	}
+	err := w.tw.WriteHeader(&tar.Header{
+		Name:    C.GoString(name),
+		Size:    int64(size),
+		Mode:    int64(mode),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return
+	}
+	*errOut = nil
+}
+
+//export goated_tar_Write
+func goated_tar_Write(handle C.ulonglong, buf *C.char, bufLen C.longlong) C.longlong {
+	w, ok := getHandle[*tarWriter](uint64(handle))
+	if !ok {
+		return -1
+	}
+	n, err := w.tw.Write(unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(bufLen)))
+	if err != nil {
+		return -1
+	}
+	return C.longlong(n)
+}
+
+//export goated_tar_WriterClose
+func goated_tar_WriterClose(handle C.ulonglong) {
+	w, ok := getHandle[*tarWriter](uint64(handle))
+	if !ok {
+		return
+	}
+	w.tw.Close()
+	w.f.Close()
+	deleteHandle(uint64(handle))
+}
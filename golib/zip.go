@@ -0,0 +1,175 @@
+package main
+
+/*
+#include <stdlib.h>
+#include <stdint.h>
+#include <stdbool.h>
+*/
+import "C"
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"unsafe"
+)
+
+// zipWriter bundles a *zip.Writer with the underlying file so
+// goated_zip_WriterClose can close both in the right order.
+type zipWriter struct {
+	f  *os.File
+	zw *zip.Writer
+}
+
+//export goated_zip_OpenReader
+func goated_zip_OpenReader(path *C.char, errOut **C.char) C.ulonglong {
+	r, err := zip.OpenReader(C.GoString(path))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newHandle(r))
+}
+
+//export goated_zip_Entries
+func goated_zip_Entries(handle C.ulonglong) C.longlong {
+	r, ok := getHandle[*zip.ReadCloser](uint64(handle))
+	if !ok {
+		return 0
+	}
+	return C.longlong(len(r.File))
+}
+
+//export goated_zip_EntryName
+func goated_zip_EntryName(handle C.ulonglong, index C.longlong) *C.char {
+	r, ok := getHandle[*zip.ReadCloser](uint64(handle))
+	if !ok || index < 0 || int(index) >= len(r.File) {
+		return nil
+	}
+	return C.CString(r.File[index].Name)
+}
+
+//export goated_zip_EntrySize
+func goated_zip_EntrySize(handle C.ulonglong, index C.longlong) C.longlong {
+	r, ok := getHandle[*zip.ReadCloser](uint64(handle))
+	if !ok || index < 0 || int(index) >= len(r.File) {
+		return 0
+	}
+	return C.longlong(r.File[index].UncompressedSize64)
+}
+
+//export goated_zip_EntryModTime
+func goated_zip_EntryModTime(handle C.ulonglong, index C.longlong) C.longlong {
+	r, ok := getHandle[*zip.ReadCloser](uint64(handle))
+	if !ok || index < 0 || int(index) >= len(r.File) {
+		return 0
+	}
+	return C.longlong(r.File[index].Modified.Unix())
+}
+
+//export goated_zip_EntryOpen
+func goated_zip_EntryOpen(handle C.ulonglong, index C.longlong, errOut **C.char) C.ulonglong {
+	r, ok := getHandle[*zip.ReadCloser](uint64(handle))
+	if !ok || index < 0 || int(index) >= len(r.File) {
+		*errOut = C.CString("invalid entry index")
+		return 0
+	}
+	rc, err := r.File[index].Open()
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newHandle(rc))
+}
+
+//export goated_zip_Read
+func goated_zip_Read(handle C.ulonglong, buf *C.char, bufLen C.longlong) C.longlong {
+	rc, ok := getHandle[io.ReadCloser](uint64(handle))
+	if !ok {
+		return -1
+	}
+	n, err := rc.Read(unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(bufLen)))
+	if n == 0 && err != nil {
+		return -1
+	}
+	return C.longlong(n)
+}
+
+//export goated_zip_EntryClose
+func goated_zip_EntryClose(handle C.ulonglong) {
+	if rc, ok := getHandle[io.ReadCloser](uint64(handle)); ok {
+		rc.Close()
+	}
+	deleteHandle(uint64(handle))
+}
+
+//export goated_zip_NewWriter
+func goated_zip_NewWriter(path *C.char, errOut **C.char) C.ulonglong {
+	f, err := os.Create(C.GoString(path))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newHandle(&zipWriter{f: f, zw: zip.NewWriter(f)}))
+}
+
+//export goated_zip_WriterCreate
+func goated_zip_WriterCreate(handle C.ulonglong, name *C.char, errOut **C.char) C.ulonglong {
+	w, ok := getHandle[*zipWriter](uint64(handle))
+	if !ok {
+		*errOut = C.CString("invalid handle")
+		return 0
+	}
+	entry, err := w.zw.Create(C.GoString(name))
+	if err != nil {
+		*errOut = C.CString(err.Error())
+		return 0
+	}
+	*errOut = nil
+	return C.ulonglong(newHandle(entry))
+}
+
+//export goated_zip_Write
+func goated_zip_Write(handle C.ulonglong, buf *C.char, bufLen C.longlong) C.longlong {
+	w, ok := getHandle[io.Writer](uint64(handle))
+	if !ok {
+		return -1
+	}
+	n, err := w.Write(unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(bufLen)))
+	if err != nil {
+		return -1
+	}
+	return C.longlong(n)
+}
+
+// goated_zip_WriterEntryClose releases the per-entry handle goated_zip_
+// WriterCreate registers for each archived file, mirroring EntryOpen's
+// EntryClose on the reader side. Callers must call this once they're done
+// writing an entry (zip.Writer entries don't need their own Close, unlike
+// the reader side's io.ReadCloser, so this only drops the handle).
+//
+//export goated_zip_WriterEntryClose
+func goated_zip_WriterEntryClose(handle C.ulonglong) {
+	deleteHandle(uint64(handle))
+}
+
+//export goated_zip_WriterClose
+func goated_zip_WriterClose(handle C.ulonglong) {
+	w, ok := getHandle[*zipWriter](uint64(handle))
+	if !ok {
+		return
+	}
+	w.zw.Close()
+	w.f.Close()
+	deleteHandle(uint64(handle))
+}
+
+//export goated_zip_Free
+func goated_zip_Free(handle C.ulonglong) {
+	if r, ok := getHandle[*zip.ReadCloser](uint64(handle)); ok {
+		r.Close()
+	}
+	deleteHandle(uint64(handle))
+}